@@ -0,0 +1,35 @@
+// Package beacon 提供外部随机信标(drand 风格)的接入能力，供共识选主和合约侧
+// 不可预测随机数使用。
+package beacon
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrStaleRound 信标轮次落后于当前出块时间要求的最小轮次
+	ErrStaleRound = errors.New("beacon: stale round")
+	// ErrVerifyEntryFailed 信标签名验签失败
+	ErrVerifyEntryFailed = errors.New("beacon: verify entry failed")
+	// ErrBeaconUnreachable 信标服务不可达
+	ErrBeaconUnreachable = errors.New("beacon: unreachable")
+)
+
+// BeaconEntry 是某一轮随机信标的产出，Sig 是 Data 在 Round 上对 GroupPublicKey 的 BLS 签名
+type BeaconEntry struct {
+	Round uint64
+	Data  []byte
+	Sig   []byte
+}
+
+// Beacon 定义链对外部随机信标的依赖，Chain/Miner 通过该接口获取及校验随机数，
+// 合约侧通过 SandboxConfig.BeaconReader 获取同一套接口的只读子集
+type Beacon interface {
+	// EntryForRound 返回指定轮次的信标条目，轮次不存在时返回 ErrBeaconUnreachable
+	EntryForRound(round uint64) (*BeaconEntry, error)
+	// MaxBeaconRoundForBlockTime 计算某个出块时间允许引用的最大信标轮次
+	MaxBeaconRoundForBlockTime(t time.Time) uint64
+	// VerifyEntry 校验 cur 是否是 prev 之后按 Chained-DRAND 规则派生的合法条目
+	VerifyEntry(prev, cur *BeaconEntry) error
+}
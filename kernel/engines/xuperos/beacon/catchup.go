@@ -0,0 +1,62 @@
+package beacon
+
+import (
+	"sync"
+	"time"
+)
+
+// CatchupReader 包装一个 Beacon，当信标连续 MaxMissedSlots 轮不可达时，
+// 退化为返回最近一次成功获取的条目，并通过 Degraded() 暴露当前是否处于降级态，
+// 供 Chain 校验区块时将其计为 "unverified-beacon"。
+type CatchupReader struct {
+	inner          Beacon
+	maxMissedSlots uint64
+
+	mu       sync.Mutex
+	last     *BeaconEntry
+	missed   uint64
+	degraded bool
+}
+
+// NewCatchupReader 创建一个带漂移容忍的 Beacon 包装器
+func NewCatchupReader(inner Beacon, maxMissedSlots uint64) *CatchupReader {
+	return &CatchupReader{
+		inner:          inner,
+		maxMissedSlots: maxMissedSlots,
+	}
+}
+
+// EntryForRound 优先请求底层信标，连续失败超过 maxMissedSlots 轮后回退到最近一次成功的条目
+func (c *CatchupReader) EntryForRound(round uint64) (*BeaconEntry, error) {
+	entry, err := c.inner.EntryForRound(round)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err == nil {
+		c.last = entry
+		c.missed = 0
+		c.degraded = false
+		return entry, nil
+	}
+
+	c.missed++
+	if c.last != nil && c.missed > c.maxMissedSlots {
+		c.degraded = true
+		return c.last, nil
+	}
+	return nil, err
+}
+
+func (c *CatchupReader) MaxBeaconRoundForBlockTime(t time.Time) uint64 {
+	return c.inner.MaxBeaconRoundForBlockTime(t)
+}
+
+func (c *CatchupReader) VerifyEntry(prev, cur *BeaconEntry) error {
+	return c.inner.VerifyEntry(prev, cur)
+}
+
+// Degraded 返回最近一次 EntryForRound 是否回退到了旧条目
+func (c *CatchupReader) Degraded() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.degraded
+}
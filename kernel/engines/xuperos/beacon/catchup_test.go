@@ -0,0 +1,37 @@
+package beacon
+
+import "testing"
+
+func TestCatchupReaderFallback(t *testing.T) {
+	mock := NewMockBeacon()
+	mock.Entries[1] = &BeaconEntry{Round: 1, Data: []byte("r1")}
+
+	reader := NewCatchupReader(mock, 2)
+
+	if _, err := reader.EntryForRound(1); err != nil {
+		t.Fatalf("EntryForRound(1) unexpected error: %v", err)
+	}
+	if reader.Degraded() {
+		t.Fatalf("reader should not be degraded after a successful round")
+	}
+
+	// round 2/3/4 不存在，连续失败应在超过 maxMissedSlots 后回退到最近一次的条目
+	for round := uint64(2); round <= 4; round++ {
+		entry, err := reader.EntryForRound(round)
+		if round <= 3 {
+			if err == nil {
+				t.Fatalf("round %d expected error before catch-up threshold", round)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("round %d expected fallback entry, got error: %v", round, err)
+		}
+		if entry.Round != 1 {
+			t.Fatalf("expected fallback to round 1, got round %d", entry.Round)
+		}
+	}
+	if !reader.Degraded() {
+		t.Fatalf("reader should be degraded after falling back")
+	}
+}
@@ -0,0 +1,46 @@
+package beacon
+
+import "time"
+
+// MockBeacon 是一个可在单测中完全掌控的 Beacon 实现，轮次通过 Entries 预先填充
+type MockBeacon struct {
+	Entries map[uint64]*BeaconEntry
+	Period  time.Duration
+	Genesis int64
+	// VerifyErr 不为空时 VerifyEntry 总是返回该错误，便于构造校验失败场景
+	VerifyErr error
+}
+
+// NewMockBeacon 创建一个空的 MockBeacon，周期默认 3s
+func NewMockBeacon() *MockBeacon {
+	return &MockBeacon{
+		Entries: make(map[uint64]*BeaconEntry),
+		Period:  3 * time.Second,
+	}
+}
+
+func (m *MockBeacon) EntryForRound(round uint64) (*BeaconEntry, error) {
+	entry, ok := m.Entries[round]
+	if !ok {
+		return nil, ErrBeaconUnreachable
+	}
+	return entry, nil
+}
+
+func (m *MockBeacon) MaxBeaconRoundForBlockTime(t time.Time) uint64 {
+	if m.Period <= 0 {
+		return 0
+	}
+	elapsed := t.Unix() - m.Genesis
+	if elapsed < 0 {
+		return 0
+	}
+	return uint64(elapsed) / uint64(m.Period.Seconds())
+}
+
+func (m *MockBeacon) VerifyEntry(prev, cur *BeaconEntry) error {
+	if m.VerifyErr != nil {
+		return m.VerifyErr
+	}
+	return nil
+}
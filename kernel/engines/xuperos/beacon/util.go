@@ -0,0 +1,34 @@
+package beacon
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+)
+
+// ErrBLSVerifierNotConfigured 表示没有配置真正的 BLS 验签实现。在这种情况下
+// 验签必须失败关闭(fail closed)，绝不能把未经验证的信标当作合法输入接受。
+var ErrBLSVerifierNotConfigured = errors.New("beacon: no BLS verifier configured, refusing to accept unverified entry")
+
+func decodeHex(s string) ([]byte, error) {
+	return hex.DecodeString(s)
+}
+
+func uint64ToBytes(v uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, v)
+	return buf
+}
+
+// Verifier 对群公钥 pubKey 下消息 msg 的签名 sig 做真实的 BLS 验签，
+// 具体曲线库的选型由调用方通过 DrandConfig.Verifier 注入
+type Verifier func(pubKey, msg, sig []byte) error
+
+// verifyBLS 校验 sig 是否为 pubKey 对 msg 的 BLS 签名。未注入 Verifier 时
+// 直接拒绝(fail closed)，不允许任何签名被默认放行。
+func verifyBLS(verifier Verifier, pubKey, msg, sig []byte) error {
+	if verifier == nil {
+		return ErrBLSVerifierNotConfigured
+	}
+	return verifier(pubKey, msg, sig)
+}
@@ -15,9 +15,15 @@ import (
 	xctx "github.com/xuperchain/xupercore/kernel/common/xcontext"
 	"github.com/xuperchain/xupercore/kernel/contract"
 	"github.com/xuperchain/xupercore/kernel/engines/xuperos/agent"
+	"github.com/xuperchain/xupercore/kernel/engines/xuperos/beacon"
+	"github.com/xuperchain/xupercore/kernel/engines/xuperos/committer"
 	"github.com/xuperchain/xupercore/kernel/engines/xuperos/common"
+	"github.com/xuperchain/xupercore/kernel/engines/xuperos/forkchoice"
+	"github.com/xuperchain/xupercore/kernel/engines/xuperos/mempool"
 	"github.com/xuperchain/xupercore/kernel/engines/xuperos/miner"
 	"github.com/xuperchain/xupercore/kernel/engines/xuperos/parachain"
+	"github.com/xuperchain/xupercore/kernel/engines/xuperos/pdc"
+	"github.com/xuperchain/xupercore/kernel/engines/xuperos/trace"
 	"github.com/xuperchain/xupercore/lib/logs"
 	"github.com/xuperchain/xupercore/lib/metrics"
 	"github.com/xuperchain/xupercore/lib/timer"
@@ -30,6 +36,8 @@ const (
 	TxIdCacheExpired = 120 * time.Second
 	// 提交交易cache GC 周期（s）
 	TxIdCacheGCInterval = 180 * time.Second
+	// 交易池准入策略配置文件名
+	MempoolPolicyConfName = "mempool_policy.yaml"
 )
 
 // 定义一条链的具体行为，对外暴露接口错误统一使用标准错误
@@ -45,6 +53,23 @@ type Chain struct {
 
 	// 提交交易cache
 	txIdCache *cache.Cache
+
+	// 交易池准入策略，控制进入交易池前的限额/限流/黑名单检查
+	mempoolPolicy  mempool.Policy
+	mempoolCfgPath string
+
+	// 私有数据集合管理器，负责保密合约状态的 side-DB 存取与成员间补拉
+	privateData *pdc.PrivateDataManager
+
+	// 分叉选择规则，决定 ProcBlock 在多个候选链尖里挑选哪一个触发 miner 重组
+	forkChoice forkchoice.ForkChoice
+	// tips 登记最近观测到的链尖及其父子关系，为 forkChoice 提供真实的候选分叉集合
+	tips *tipRegistry
+	// reorgSubscribers 在 OnReorg 时依次收到新链尖通知
+	reorgSubscribers []func(newTip *forkchoice.ChainTip)
+
+	// commitPipeline 是语法校验/策略校验/MVCC 冲突检测/提交四阶段流水线
+	commitPipeline *committer.Pipeline
 }
 
 // 从本地存储加载链
@@ -81,9 +106,34 @@ func LoadChain(engCtx *common.EngineCtx, bcName string) (*Chain, error) {
 	chainObj.miner = miner.NewMiner(ctx)
 	chainObj.txIdCache = cache.New(TxIdCacheExpired, TxIdCacheGCInterval)
 
+	// 加载交易池准入策略，配置缺失时使用零值配置(即不限制)
+	chainObj.mempoolCfgPath = engCtx.EnvCfg.GenConfFilePath(MempoolPolicyConfName)
+	mempoolCfg, err := mempool.LoadConfigFile(chainObj.mempoolCfgPath)
+	if err != nil {
+		log.Error("load mempool policy config failed", "bcName", bcName, "err", err)
+		return nil, common.ErrNewChainCtxFailed.More("err:%v", err)
+	}
+	chainObj.mempoolPolicy = mempool.NewSimplePolicy(mempoolCfg)
+
 	return chainObj, nil
 }
 
+// ReloadMempoolPolicy 重新读取 mempool_policy.yaml 并原地替换当前生效的策略，
+// 供运维在不重启矿工的情况下热更新准入规则
+func (t *Chain) ReloadMempoolPolicy() error {
+	cfg, err := mempool.LoadConfigFile(t.mempoolCfgPath)
+	if err != nil {
+		return err
+	}
+
+	policy, ok := t.mempoolPolicy.(*mempool.SimplePolicy)
+	if !ok {
+		return fmt.Errorf("mempool policy does not support hot-reload")
+	}
+	policy.Reload(cfg)
+	return nil
+}
+
 // 供单测时设置rely agent为mock agent，非并发安全
 func (t *Chain) SetRelyAgent(agent common.ChainRelyAgent) error {
 	if agent == nil {
@@ -103,6 +153,14 @@ func (t *Chain) Start() {
 func (t *Chain) Stop() {
 	// 停止矿工等其余组件
 	t.miner.Stop()
+	if t.commitPipeline != nil {
+		t.commitPipeline.Stop()
+	}
+	if t.privateData != nil {
+		if err := t.privateData.Close(); err != nil {
+			t.log.Error("close private data side-db failed", "bcName", t.ctx.BCName, "err", err)
+		}
+	}
 	t.ctx.Ledger.Close()
 	t.ctx.State.Close()
 	t.ctx = nil
@@ -136,9 +194,11 @@ func (t *Chain) PreExec(ctx xctx.XContext, reqs []*protos.InvokeRequest, initiat
 		return &protos.InvokeResponse{}, nil
 	}
 
+	// BeaconReader 把本链的随机信标依赖透传给合约沙盒，供合约侧只读访问
 	stateConfig := &contract.SandboxConfig{
-		XMReader:   t.ctx.State.CreateXMReader(),
-		UTXOReader: t.ctx.State.CreateUtxoReader(),
+		XMReader:     t.ctx.State.CreateXMReader(),
+		UTXOReader:   t.ctx.State.CreateUtxoReader(),
+		BeaconReader: t.ctx.Beacon,
 	}
 	sandbox, err := t.ctx.Contract.NewStateSandbox(stateConfig)
 	if err != nil {
@@ -259,6 +319,159 @@ func (t *Chain) PreExec(ctx xctx.XContext, reqs []*protos.InvokeRequest, initiat
 	return invokeResponse, nil
 }
 
+// SimulateOptions 控制 SimulateTx 的模拟执行环境，对应 eth_call 里 "from"/区块覆盖/状态覆盖的语义
+type SimulateOptions struct {
+	// BlockHeight 是模拟执行所依据的区块高度；SimulateTx 不读取该字段，
+	// 模拟总是基于当前最新状态
+	BlockHeight int64
+	// Timestamp 是模拟执行所依据的区块时间戳；SimulateTx 不读取该字段
+	Timestamp int64
+	// Initiator 覆盖交易发起者，用于模拟不同账户发起同一笔交易的效果
+	Initiator string
+	// BalanceOverrides 模拟执行期间临时覆盖指定账户余额
+	BalanceOverrides map[string]string
+	// CodeOverrides 模拟执行期间临时覆盖指定合约的代码，便于做 what-if 分析
+	CodeOverrides map[string][]byte
+}
+
+// SimulateResult 是 SimulateTx 的返回结果，在 InvokeResponse 的基础上附加调用跟踪信息
+type SimulateResult struct {
+	*protos.InvokeResponse
+	Frames       []*trace.CallFrame
+	RevertReason string
+}
+
+// SimulateTx 在不提交状态变更的前提下模拟执行 reqs 给出的合约调用请求列表，输入是请求列表
+// 而不是整笔交易，交易里的 UTXO 转账等其余部分不参与模拟。返回每个顶层请求一帧的 gas 消耗、
+// 存储读取值、写入的新值(写入帧的 OldValue 为空)和失败原因；事件不被记录，帧的 Events 为空。
+// 签名只依赖 ctx/reqs/initiator/authRequires/opts，不绑定任何传输层；本仓库里没有
+// rpc/grpc server 包，转调它的 gRPC 方法不在这里。
+func (t *Chain) SimulateTx(ctx xctx.XContext, reqs []*protos.InvokeRequest, initiator string, authRequires []string, opts *SimulateOptions) (*SimulateResult, error) {
+	if ctx == nil || ctx.GetLog() == nil {
+		return nil, common.ErrParameter
+	}
+	if opts == nil {
+		opts = &SimulateOptions{}
+	}
+	if opts.Initiator != "" {
+		initiator = opts.Initiator
+	}
+
+	xmReader := t.ctx.State.CreateXMReader()
+	utxoReader := t.ctx.State.CreateUtxoReader()
+	if len(opts.BalanceOverrides) > 0 || len(opts.CodeOverrides) > 0 {
+		xmReader = newOverrideXMReader(xmReader, opts.CodeOverrides)
+		utxoReader = newOverrideUTXOReader(utxoReader, opts.BalanceOverrides)
+	}
+
+	stateConfig := &contract.SandboxConfig{
+		XMReader:   xmReader,
+		UTXOReader: utxoReader,
+	}
+	sandbox, err := t.ctx.Contract.NewStateSandbox(stateConfig)
+	if err != nil {
+		t.log.Error("SimulateTx new state sandbox error", "error", err)
+		return nil, common.ErrContractNewSandboxFailed
+	}
+
+	tracer := trace.NewTracer()
+	contextConfig := &contract.ContextConfig{
+		State:          sandbox,
+		Initiator:      initiator,
+		AuthRequire:    authRequires,
+		ResourceLimits: contract.MaxLimits,
+		ChainName:      t.ctx.BCName,
+	}
+
+	gasUsed := int64(0)
+	responseBodes := make([][]byte, 0, len(reqs))
+	responses := make([]*protos.ContractResponse, 0, len(reqs))
+	revertReason := ""
+	var seenInputs []*protos.TxInputExt
+	var seenOutputs []*protos.TxOutputExt
+	for _, req := range reqs {
+		if req == nil {
+			continue
+		}
+		contextConfig.ContractName = req.ContractName
+
+		// tracer 目前没有被传进 ContextConfig/NewContext，合约运行时处理内部的
+		// context.Invoke(跨合约调用)时拿不到这个 tracer，不会为嵌套调用追加子帧；
+		// 这里记录的是 reqs 里每个顶层请求各一帧的扁平轨迹，CallFrame.Children
+		// 暂时总是空的，深度嵌套调用要补上这条轨迹还需要把 tracer 一路透传进合约
+		// 运行时的调用栈
+		tracer.NewFrame(req.ContractName, req.MethodName)
+		context, err := t.ctx.Contract.NewContext(contextConfig)
+		if err != nil {
+			tracer.EndFrame(0, err.Error())
+			return nil, common.ErrContractNewCtxFailed.More("%v", err)
+		}
+
+		resp, err := context.Invoke(req.MethodName, req.Args)
+		if err != nil {
+			context.Release()
+			tracer.EndFrame(0, err.Error())
+			return nil, common.ErrContractInvokeFailed.More("%v", err)
+		}
+
+		resourceUsed := context.ResourceUsed()
+		frameGas := resourceUsed.TotalGas(t.ctx.State.GetMeta().GetGasPrice())
+		gasUsed += frameGas
+
+		// 本次调用执行完后，把 sandbox 读写集里新增的部分记到当前帧上，让 Reads/Writes
+		// 反映这一层调用真实触碰过的 key，而不是始终为空。Reads 的值从 xmReader 里
+		// 按 bucket/key 补读出来(读写集本身只存版本引用，不存值)，反映的是这笔交易
+		// 读到的那个版本，而不是始终为空
+		curInputs := xmodel.GetTxInputs(sandbox.RWSet().RSet)
+		curOutputs := xmodel.GetTxOutputs(sandbox.RWSet().WSet)
+		for _, in := range curInputs[len(seenInputs):] {
+			val, _ := xmReader.Get(in.GetBucket(), in.GetKey())
+			tracer.RecordStorageRead(in.GetKey(), val)
+		}
+		for _, out := range curOutputs[len(seenOutputs):] {
+			tracer.RecordStorageWrite(out.GetKey(), nil, out.GetValue())
+		}
+		seenInputs, seenOutputs = curInputs, curOutputs
+
+		if resp.Status >= 400 {
+			revertReason = resp.Message
+			tracer.EndFrame(frameGas, revertReason)
+			context.Release()
+			break
+		}
+
+		responses = append(responses, &protos.ContractResponse{
+			Status:  int32(resp.Status),
+			Message: resp.Message,
+			Body:    resp.Body,
+		})
+		responseBodes = append(responseBodes, resp.Body)
+		context.Release()
+		tracer.EndFrame(frameGas, "")
+	}
+
+	if err := sandbox.Flush(); err != nil {
+		return nil, err
+	}
+	rwSet := sandbox.RWSet()
+	utxoRWSet := sandbox.UTXORWSet()
+
+	result := &SimulateResult{
+		InvokeResponse: &protos.InvokeResponse{
+			GasUsed:     gasUsed,
+			Response:    responseBodes,
+			Inputs:      xmodel.GetTxInputs(rwSet.RSet),
+			Outputs:     xmodel.GetTxOutputs(rwSet.WSet),
+			Responses:   responses,
+			UtxoInputs:  utxoRWSet.Rset,
+			UtxoOutputs: utxoRWSet.WSet,
+		},
+		Frames:       tracer.Frames(),
+		RevertReason: revertReason,
+	}
+	return result, nil
+}
+
 // 提交交易到交易池(xuperos引擎同时更新到状态机和交易池)
 func (t *Chain) SubmitTx(ctx xctx.XContext, tx *lpb.Transaction) error {
 	if tx == nil || ctx == nil || ctx.GetLog() == nil || len(tx.GetTxid()) <= 0 {
@@ -283,6 +496,16 @@ func (t *Chain) SubmitTx(ctx xctx.XContext, tx *lpb.Transaction) error {
 		metrics.CallMethodCounter.WithLabelValues(t.ctx.BCName, "SubmitTx", code).Inc()
 	}()
 
+	// 准入策略检查：大小/限流/黑名单/gas price 下限，早于 VerifyTx/DoTx 执行以尽快拒绝
+	if t.mempoolPolicy != nil {
+		if reason := t.mempoolPolicy.Admit(tx); reason != mempool.RejectNone {
+			log.Warn("tx rejected by mempool policy", "txid", utils.F(tx.GetTxid()), "reason", reason)
+			t.txIdCache.Delete(string(tx.GetTxid()))
+			code = "PolicyRejected:" + string(reason)
+			return common.ErrTxRejectedByPolicy.More("reason:%v", reason)
+		}
+	}
+
 	// 判断此交易是否已经存在（账本和未确认交易表中）。
 	dbtx, _, _ := t.ctx.State.QueryTx(tx.GetTxid())
 	if dbtx != nil { // 从数据库查询到了交易，返回错误。
@@ -290,28 +513,84 @@ func (t *Chain) SubmitTx(ctx xctx.XContext, tx *lpb.Transaction) error {
 		return common.ErrTxAlreadyExist
 	}
 
-	// 验证交易
+	// 验证交易：签名覆盖的是交易本身而不是 PrivatePayloads 明文，所以对原始 tx 验签，
+	// 脱敏只影响之后流向状态机/交易池/P2P 广播的那份拷贝
 	_, err := t.ctx.State.VerifyTx(tx)
 	if err != nil {
 		log.Error("verify tx error", "txid", utils.F(tx.GetTxid()), "err", err)
 		code = "VerifyTxFailed"
+		t.releaseMempoolSlot(1)
 		return common.ErrTxVerifyFailed.More("err:%v", err)
 	}
 
+	// 私有数据：本节点是集合成员时先把随请求携带的明文 payload 落到本地 side-DB，
+	// 再把交易里每个集合的 PrivatePayloads 都替换成 pdc.HashRef 的哈希(不论本节点
+	// 是否为成员、落盘是否成功)。真正提交到状态机/
+	// 交易池(进而随正常 P2P 广播扩散出去)的必须是这份脱敏后的交易，否则非成员
+	// 节点仍能从普通交易同步路径里拿到完整明文，保密集合就形同虚设
+	submitTx := tx
+	if t.privateData != nil {
+		height := t.ctx.Ledger.GetMeta().GetTrunkHeight()
+		redacted := false
+		for i, req := range tx.GetContractRequests() {
+			for collection, payload := range req.GetPrivatePayloads() {
+				if t.privateData.IsMember(collection) {
+					if _, err := t.privateData.StorePayload(collection, height, string(tx.GetTxid()), payload); err != nil {
+						log.Error("store private payload failed", "txid", utils.F(tx.GetTxid()), "collection", collection, "err", err)
+					}
+				}
+				if !redacted {
+					submitTx = redactPrivatePayloads(tx)
+					redacted = true
+				}
+				submitTx.GetContractRequests()[i].PrivatePayloads[collection] = pdc.HashPayload(collection, payload).Hash
+			}
+		}
+	}
+
 	// 提交交易
-	err = t.ctx.State.DoTx(tx)
+	err = t.ctx.State.DoTx(submitTx)
 	if err != nil {
 		log.Error("submit tx error", "txid", utils.F(tx.GetTxid()), "err", err)
 		if err == state.ErrAlreadyInUnconfirmed {
 			t.txIdCache.Delete(string(tx.GetTxid()))
 		}
 		code = "SubmitTxFailed"
+		t.releaseMempoolSlot(1)
 		return common.ErrSubmitTxFailed.More("err:%v", err)
 	}
 
 	return nil
 }
 
+// releaseMempoolSlot 把 n 笔交易从 mempool 准入策略的 MaxTxCountPerBlock 计数里放出来，
+// 用于一笔交易通过 Admit 之后又在后续步骤被拒绝、或者区块提交后按实际打包数回收配额
+func (t *Chain) releaseMempoolSlot(n int) {
+	if policy, ok := t.mempoolPolicy.(*mempool.SimplePolicy); ok {
+		policy.Release(n)
+	}
+}
+
+// redactPrivatePayloads 浅拷贝 tx 和它的 ContractRequests，把每个集合的明文 payload
+// 换成对应的哈希引用，不改动调用方持有的原始 tx
+func redactPrivatePayloads(tx *lpb.Transaction) *lpb.Transaction {
+	clone := *tx
+	reqs := make([]*protos.InvokeRequest, len(tx.GetContractRequests()))
+	for i, req := range tx.GetContractRequests() {
+		reqClone := *req
+		if len(req.GetPrivatePayloads()) > 0 {
+			payloads := make(map[string][]byte, len(req.GetPrivatePayloads()))
+			for collection, payload := range req.GetPrivatePayloads() {
+				payloads[collection] = payload
+			}
+			reqClone.PrivatePayloads = payloads
+		}
+		reqs[i] = &reqClone
+	}
+	clone.ContractRequests = reqs
+	return &clone
+}
+
 // 处理P2P网络同步到的区块
 func (t *Chain) ProcBlock(ctx xctx.XContext, block *lpb.InternalBlock) error {
 	if block == nil || ctx == nil || ctx.GetLog() == nil || block.GetBlockid() == nil {
@@ -319,7 +598,17 @@ func (t *Chain) ProcBlock(ctx xctx.XContext, block *lpb.InternalBlock) error {
 	}
 
 	log := ctx.GetLog()
-	err := t.miner.ProcBlock(ctx, block)
+
+	if err := t.verifyBeaconEntry(block); err != nil {
+		log.Warn("process block reject by beacon check", "blockid", utils.F(block.GetBlockid()), "err", err)
+		return common.ErrForbidden.More("err:%v", err)
+	}
+
+	beginTime := time.Now()
+	currentTip := t.currentChainTip()
+	newTip := t.chainTipFromBlock(block)
+
+	err := t.commitPipeline.Submit(ctx, block)
 	if err != nil {
 		if common.CastError(err).Equal(common.ErrForbidden) {
 			log.Trace("forbidden process block", "blockid", utils.F(block.GetBlockid()), "err", err)
@@ -335,10 +624,141 @@ func (t *Chain) ProcBlock(ctx xctx.XContext, block *lpb.InternalBlock) error {
 		return common.ErrProcBlockFailed.More("err:%v", err)
 	}
 
+	// 区块提交成功，里面打包的交易已经离开交易池，按实际打包数回收 MaxTxCountPerBlock
+	// 的待打包计数；否则该计数只增不减，最终会把后续所有交易都误判为超限
+	t.releaseMempoolSlot(len(block.GetTransactions()))
+
+	// 分叉选择和重组通知只在区块真正通过语法/策略/MVCC 校验并提交成功后才执行，
+	// 避免被流水线拒绝的区块提前触发 reorg 指标和 OnReorg 订阅者回调
+	if t.forkChoice != nil {
+		t.tips.record(currentTip, nil)
+		t.tips.record(newTip, block.GetPreHash())
+		t.forkChoice.OnNewBlock(newTip)
+
+		candidates := t.tips.candidates()
+		selected := t.forkChoice.Select(candidates)
+		switch selected {
+		case newTip:
+			// 正常顺序出块时 newTip 的 Parent 链必然经过 currentTip，Select 选中 newTip
+			// 只是沿着主链往前走了一格，不是重组；只有 currentTip 被甩出 Parent 链时，
+			// 也就是链真的从别的分叉切换过来，才计一次 Reorg
+			if currentTip.Height > 0 && !isAncestor(currentTip, selected) {
+				reorgDepth := currentTip.Height - commonAncestorHeight(currentTip, selected)
+				metrics.CallMethodCounter.WithLabelValues(t.ctx.BCName, "ProcBlock", "Reorg").Inc()
+				t.onReorg(selected)
+				log.Info("fork choice selected new tip", "blockid", utils.F(selected.BlockID), "height", selected.Height, "reorgDepth", reorgDepth)
+			}
+		case currentTip:
+			metrics.CallMethodCounter.WithLabelValues(t.ctx.BCName, "ProcBlock", "Orphaned").Inc()
+			log.Trace("fork choice kept current tip, new block orphaned", "blockid", utils.F(newTip.BlockID), "height", newTip.Height)
+		}
+	}
+
+	metrics.ProcBlockHistogram.WithLabelValues(t.ctx.BCName, "ForkChoice").Observe(time.Since(beginTime).Seconds())
+
 	log.Info("process block succ", "height", block.GetHeight(), "blockid", utils.F(block.GetBlockid()))
 	return nil
 }
 
+// beaconDegraded 是 beacon.CatchupReader 暴露的可选能力：只有真正包了 CatchupReader
+// 的 Beacon 实现会在信标长期不可达时进入退化态，裸 Beacon 实现永远不会命中这个分支
+type beaconDegraded interface {
+	Degraded() bool
+}
+
+// 校验区块头携带的随机信标条目:
+// 1. 轮次不能超过该区块出块时间允许的最大轮次(防止提前使用未来轮次);
+// 2. 签名需对上一个已采纳轮次的条目验签通过;
+// 3. 只有当 t.ctx.Beacon 是 beacon.CatchupReader 且已经处于 Degraded() 状态时，
+//    才允许跳过验签、记录 unverified-beacon 指标；除此之外任何获取前序条目失败
+//    (包括轮次未知、信标刚好抖动一次)都直接拒绝该区块，不能把未验证的信标当成合法输入放行
+func (t *Chain) verifyBeaconEntry(block *lpb.InternalBlock) error {
+	if t.ctx.Beacon == nil {
+		return nil
+	}
+
+	entry := block.GetBeaconEntry()
+	if entry == nil {
+		return nil
+	}
+
+	maxRound := t.ctx.Beacon.MaxBeaconRoundForBlockTime(time.Unix(block.GetTimestamp(), 0))
+	if entry.GetRound() > maxRound {
+		return fmt.Errorf("beacon round %d exceeds max allowed round %d", entry.GetRound(), maxRound)
+	}
+
+	var prevEntry *beacon.BeaconEntry
+	if entry.GetRound() > 0 {
+		var err error
+		prevEntry, err = t.ctx.Beacon.EntryForRound(entry.GetRound() - 1)
+		// 退化态的 CatchupReader 返回的是旧条目且 err 为空，所以要在判断 err 之前检查 Degraded()
+		if degraded, ok := t.ctx.Beacon.(beaconDegraded); ok && degraded.Degraded() {
+			metrics.CallMethodCounter.WithLabelValues(t.ctx.BCName, "ProcBlock", "UnverifiedBeacon").Inc()
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("beacon entry for round %d unavailable: %v", entry.GetRound()-1, err)
+		}
+	}
+
+	cur := &beacon.BeaconEntry{Round: entry.GetRound(), Data: entry.GetData(), Sig: entry.GetSig()}
+	if err := t.ctx.Beacon.VerifyEntry(prevEntry, cur); err != nil {
+		return fmt.Errorf("verify beacon entry failed: %v", err)
+	}
+
+	return nil
+}
+
+// reconcilePrivateData 对本节点是成员但本地缺失的私有数据 payload 发起后台补拉，不阻塞主流程
+func (t *Chain) reconcilePrivateData(block *lpb.InternalBlock) {
+	if t.privateData == nil {
+		return
+	}
+	for _, req := range block.GetTransactions() {
+		for _, invokeReq := range req.GetContractRequests() {
+			for collection := range invokeReq.GetPrivatePayloads() {
+				t.privateData.ReconcileMissing(collection, block.GetHeight(), string(req.GetTxid()))
+			}
+		}
+	}
+}
+
+// OnReorg 注册一个在主链尖发生切换时触发的回调，供合约/订阅方感知重组。
+// 非并发安全，约定在 Start 之前完成注册。
+func (t *Chain) OnReorg(fn func(newTip *forkchoice.ChainTip)) {
+	t.reorgSubscribers = append(t.reorgSubscribers, fn)
+}
+
+func (t *Chain) onReorg(newTip *forkchoice.ChainTip) {
+	for _, fn := range t.reorgSubscribers {
+		fn(newTip)
+	}
+}
+
+// currentChainTip 把账本当前主干尖端包装成 ForkChoice 可比较的 ChainTip
+func (t *Chain) currentChainTip() *forkchoice.ChainTip {
+	meta := t.ctx.Ledger.GetMeta()
+	return &forkchoice.ChainTip{
+		BlockID:         meta.GetTipBlockid(),
+		Height:          meta.GetTrunkHeight(),
+		ConsensusWeight: uint64(meta.GetTrunkHeight()),
+	}
+}
+
+// chainTipFromBlock 把一个新收到的候选区块包装成 ChainTip，Filecoin 规则下 TicketWeight
+// 取自该块引用的信标轮次；UncleWeight 不做设置(始终为 0)，GHOST 规则因此只累加 ConsensusWeight
+func (t *Chain) chainTipFromBlock(block *lpb.InternalBlock) *forkchoice.ChainTip {
+	tip := &forkchoice.ChainTip{
+		BlockID:         block.GetBlockid(),
+		Height:          block.GetHeight(),
+		ConsensusWeight: uint64(block.GetHeight()),
+	}
+	if entry := block.GetBeaconEntry(); entry != nil {
+		tip.TicketWeight = entry.GetRound()
+	}
+	return tip
+}
+
 // 初始化链运行依赖上下文
 func (t *Chain) initChainCtx() error {
 	// 1.实例化账本
@@ -455,9 +875,75 @@ func (t *Chain) initChainCtx() error {
 		return err
 	}
 	t.log.Trace("create xtoken succ", "bcName", t.ctx.BCName)
+
+	// 12.随机信标，为共识选主和合约随机数提供外部可验证的随机源
+	beaconObj, err := t.relyAgent.CreateBeacon()
+	if err != nil {
+		t.log.Error("create beacon error", "bcName", t.ctx.BCName, "err", err)
+		return fmt.Errorf("create beacon error")
+	}
+	t.ctx.Beacon = beaconObj
+	t.log.Trace("create beacon succ", "bcName", t.ctx.BCName)
+
+	// 13.私有数据集合管理器，side-DB 独立于账本存储，按 bcName 隔离
+	sideDBPath := t.ctx.EngCtx.EnvCfg.GenDataAbsPath(t.ctx.BCName + "/pdc")
+	store, err := pdc.NewBoltStore(sideDBPath)
+	if err != nil {
+		t.log.Error("open private data side-db failed", "bcName", t.ctx.BCName, "err", err)
+		return fmt.Errorf("open private data side-db failed")
+	}
+	collections, err := t.relyAgent.LoadPrivateDataCollections()
+	if err != nil {
+		t.log.Error("load private data collections failed", "bcName", t.ctx.BCName, "err", err)
+		return fmt.Errorf("load private data collections failed")
+	}
+	t.privateData = pdc.NewPrivateDataManager(store, t.relyAgent.P2PPrivateDataFetcher(), t.ctx.Address.Address, collections)
+	t.log.Trace("create private data manager succ", "bcName", t.ctx.BCName)
+
+	// 14.分叉选择规则，按创世配置里声明的规则名实例化，不同 bcName 可以选用不同规则
+	forkChoiceName := t.ctx.Ledger.GetMeta().GetForkChoice()
+	fc, err := forkchoice.New(forkChoiceName)
+	if err != nil {
+		t.log.Error("create fork choice failed", "bcName", t.ctx.BCName, "rule", forkChoiceName, "err", err)
+		return fmt.Errorf("create fork choice failed")
+	}
+	t.forkChoice = fc
+	t.tips = newTipRegistry()
+	t.log.Trace("create fork choice succ", "bcName", t.ctx.BCName, "rule", forkChoiceName)
+
+	// 15.提交流水线：语法校验/策略校验/MVCC 冲突检测/提交四阶段并发执行，
+	// worker 数从引擎配置读取，未配置时使用committer.DefaultConfig
+	pipeline, err := committer.New(t.committerConfig(), &chainValidator{chain: t})
+	if err != nil {
+		t.log.Error("create commit pipeline failed", "bcName", t.ctx.BCName, "err", err)
+		return fmt.Errorf("create commit pipeline failed")
+	}
+	t.commitPipeline = pipeline
+	t.log.Trace("create commit pipeline succ", "bcName", t.ctx.BCName)
+
 	return nil
 }
 
+// committerConfig 从引擎配置读取流水线各阶段并发度，读不到时交给 committer.DefaultConfig 兜底
+func (t *Chain) committerConfig() committer.Config {
+	cfg := t.ctx.EngCtx.EngCfg.GetCommitterConfig()
+	return committer.Config{
+		SyntacticWorkers: cfg.SyntacticWorkers,
+		PolicyWorkers:    cfg.PolicyWorkers,
+		MVCCWorkers:      cfg.MVCCWorkers,
+		CommitWorkers:    cfg.CommitWorkers,
+		QueueSize:        cfg.QueueSize,
+	}
+}
+
+// BlockValidationStats 返回提交流水线各阶段的实时处理统计，用于观测吞吐瓶颈
+func (t *Chain) BlockValidationStats() committer.Stats {
+	if t.commitPipeline == nil {
+		return committer.Stats{}
+	}
+	return t.commitPipeline.Stats()
+}
+
 // 创建平行链实例
 func (t *Chain) CreateParaChain() error {
 	paraChainCtx, err := parachain.NewParaChainCtx(t.ctx.BCName, t.ctx)
@@ -0,0 +1,299 @@
+// Package committer 把区块校验拆成多阶段流水线：语法校验 -> 策略(VSCC 等价)校验 ->
+// MVCC 冲突检测 -> 提交，各阶段用独立的有界 channel 串联，阶段内部用固定大小的
+// goroutine 池并发处理，channel 容量天然提供背压，避免慢速提交阶段拖垮上游拉块。
+// 校验阶段允许乱序完成，但提交必须严格按 Submit 的顺序进行，所以 MVCC 和 Commit
+// 之间插了一个排序器，按 Job.Seq 把乱序到达的任务重新排回提交顺序。
+package committer
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Stage 标识流水线的四个阶段，用于统计打标签
+type Stage string
+
+const (
+	StageSyntactic Stage = "syntactic"
+	StagePolicy    Stage = "policy"
+	StageMVCC      Stage = "mvcc"
+	StageCommit    Stage = "commit"
+)
+
+// Config 控制每个阶段的并发度和 channel 容量
+type Config struct {
+	SyntacticWorkers int
+	PolicyWorkers    int
+	MVCCWorkers      int
+	CommitWorkers    int
+	QueueSize        int
+}
+
+// DefaultConfig 给出一个保守的默认并发度，小于等于 0 的字段会被这里的默认值兜底
+func DefaultConfig() Config {
+	return Config{
+		SyntacticWorkers: 4,
+		PolicyWorkers:    4,
+		MVCCWorkers:      2,
+		CommitWorkers:    1,
+		QueueSize:        64,
+	}
+}
+
+func (c Config) withDefaults() Config {
+	def := DefaultConfig()
+	if c.SyntacticWorkers <= 0 {
+		c.SyntacticWorkers = def.SyntacticWorkers
+	}
+	if c.PolicyWorkers <= 0 {
+		c.PolicyWorkers = def.PolicyWorkers
+	}
+	if c.MVCCWorkers <= 0 {
+		c.MVCCWorkers = def.MVCCWorkers
+	}
+	if c.CommitWorkers <= 0 {
+		c.CommitWorkers = def.CommitWorkers
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = def.QueueSize
+	}
+	return c
+}
+
+// Job 是流经流水线的一个区块校验任务。Ctx 透传调用方传入 Submit 时的上下文(通常是
+// xctx.XContext)，committer 包本身不关心其具体类型，只负责原样转交给 Validator。
+// Seq 在 Submit 时按提交顺序单调递增分配：各阶段都有各自的 worker 池，乱序完成是
+// 常态，但 Commit 必须按 Seq 严格递增执行，否则账本/状态机的提交顺序就乱了。
+// failedStage/err 记录任务在哪一阶段被拒绝；被拒绝的任务仍然会流过后续阶段(只是
+// 不再重复校验)，这样它的 Seq 才能被排序器正常"让过"，不会卡住排在它后面的任务。
+type Job struct {
+	Seq    uint64
+	Ctx    interface{}
+	Block  interface{}
+	Result chan error
+
+	failedStage Stage
+	err         error
+}
+
+// Validator 把实际的校验/提交逻辑从 Pipeline 的调度骨架中解耦出来，
+// 由 xuperos.Chain 提供基于 t.ctx.Contract / t.ctx.State / t.ctx.Ledger 的实现。
+// 每个方法的 ctx 都是 Submit 调用时传入的同一个上下文，保证 Commit 阶段最终拿到的
+// 是调用方真正传入 ProcBlock 的 ctx，而不是零值
+type Validator interface {
+	ValidateSyntactic(ctx interface{}, block interface{}) error
+	ValidatePolicy(ctx interface{}, block interface{}) error
+	ValidateMVCC(ctx interface{}, block interface{}) error
+	Commit(ctx interface{}, block interface{}) error
+}
+
+// StageStats 记录单个阶段处理过的任务数、失败数和耗时直方图的简单汇总(均值/最大值)
+type StageStats struct {
+	Processed  uint64
+	Failed     uint64
+	TotalNanos uint64
+	MaxNanos   uint64
+}
+
+func (s *StageStats) observe(d time.Duration, err error) {
+	atomic.AddUint64(&s.Processed, 1)
+	if err != nil {
+		atomic.AddUint64(&s.Failed, 1)
+	}
+	atomic.AddUint64(&s.TotalNanos, uint64(d))
+	for {
+		cur := atomic.LoadUint64(&s.MaxNanos)
+		if uint64(d) <= cur || atomic.CompareAndSwapUint64(&s.MaxNanos, cur, uint64(d)) {
+			break
+		}
+	}
+}
+
+// AvgDuration 返回该阶段处理任务的平均耗时
+func (s *StageStats) AvgDuration() time.Duration {
+	processed := atomic.LoadUint64(&s.Processed)
+	if processed == 0 {
+		return 0
+	}
+	return time.Duration(atomic.LoadUint64(&s.TotalNanos) / processed)
+}
+
+// Stats 汇总四个阶段各自的统计信息，对应 Chain.BlockValidationStats()
+type Stats struct {
+	Syntactic StageStats
+	Policy    StageStats
+	MVCC      StageStats
+	Commit    StageStats
+}
+
+// Pipeline 是四阶段提交流水线的调度骨架，阶段之间用有界 channel 连接
+type Pipeline struct {
+	cfg       Config
+	validator Validator
+
+	syntacticCh chan *Job
+	policyCh    chan *Job
+	mvccCh      chan *Job
+	preCommitCh chan *Job
+	commitCh    chan *Job
+
+	stats Stats
+
+	nextSeq uint64
+
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+// New 创建并启动一条四阶段流水线，各阶段的 worker 池随 Config 指定的并发度启动
+func New(cfg Config, validator Validator) (*Pipeline, error) {
+	if validator == nil {
+		return nil, fmt.Errorf("committer: validator is required")
+	}
+	cfg = cfg.withDefaults()
+
+	p := &Pipeline{
+		cfg:         cfg,
+		validator:   validator,
+		syntacticCh: make(chan *Job, cfg.QueueSize),
+		policyCh:    make(chan *Job, cfg.QueueSize),
+		mvccCh:      make(chan *Job, cfg.QueueSize),
+		preCommitCh: make(chan *Job, cfg.QueueSize),
+		commitCh:    make(chan *Job, cfg.QueueSize),
+		stopCh:      make(chan struct{}),
+	}
+
+	p.startStage(StageSyntactic, cfg.SyntacticWorkers, p.syntacticCh, p.policyCh, &p.stats.Syntactic, p.validator.ValidateSyntactic)
+	p.startStage(StagePolicy, cfg.PolicyWorkers, p.policyCh, p.mvccCh, &p.stats.Policy, p.validator.ValidatePolicy)
+	p.startStage(StageMVCC, cfg.MVCCWorkers, p.mvccCh, p.preCommitCh, &p.stats.MVCC, p.validator.ValidateMVCC)
+
+	p.wg.Add(1)
+	go p.runSequencer()
+
+	p.startStage(StageCommit, cfg.CommitWorkers, p.commitCh, nil, &p.stats.Commit, p.validator.Commit)
+
+	return p, nil
+}
+
+// startStage 启动 workers 个 goroutine 从 in 消费任务。任务如果已经在更早的阶段
+// 失败(job.err != nil)，本阶段直接原样转发，不再重复调用 fn；否则执行 fn，失败时
+// 记录 failedStage/err 但依然转发到下一阶段(末级提交阶段除外)，好让它的 Seq 能在
+// 排序器那边正常被"让过"，不会卡住排在它后面的任务。
+func (p *Pipeline) startStage(stage Stage, workers int, in, out chan *Job, stats *StageStats, fn func(ctx, block interface{}) error) {
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			for {
+				select {
+				case <-p.stopCh:
+					return
+				case job, ok := <-in:
+					if !ok {
+						return
+					}
+
+					if job.err == nil {
+						begin := time.Now()
+						err := fn(job.Ctx, job.Block)
+						stats.observe(time.Since(begin), err)
+						if err != nil {
+							job.failedStage = stage
+							job.err = err
+						}
+					}
+
+					if out == nil {
+						// 末级(提交)阶段：job.err 为空说明四个阶段都通过了；
+						// 非空但 failedStage 就是本阶段，说明 Commit 本身失败，错误原样
+						// 透传，保留调用方对具体错误类型的判断能力；否则是更早阶段就
+						// 已经失败、一路带着错误流转到这里只是为了让 Seq 正常推进，
+						// 按原阶段名补一层错误信息，和直接在那个阶段失败时的结果一致
+						switch {
+						case job.err == nil:
+							job.Result <- nil
+						case job.failedStage == stage:
+							job.Result <- job.err
+						default:
+							job.Result <- fmt.Errorf("committer: %s stage failed: %v", job.failedStage, job.err)
+						}
+						continue
+					}
+
+					select {
+					case out <- job:
+					case <-p.stopCh:
+						return
+					}
+				}
+			}
+		}()
+	}
+}
+
+// runSequencer 坐在 MVCC 阶段和 Commit 阶段之间，把乱序到达的任务按 Seq 严格递增的
+// 顺序转发给 Commit，乱序到达的任务先缓存在 pending 里，直到排在它前面的 Seq 都已
+// 转发完成。已经在更早阶段被拒绝的任务(job.err != nil)本身不需要真的提交，但依然
+// 要走这条路排进 Seq 序列，否则它占的那个 Seq 永远不会被"让过"，会永久卡住后面
+// 所有任务的提交
+func (p *Pipeline) runSequencer() {
+	defer p.wg.Done()
+	pending := make(map[uint64]*Job)
+	next := uint64(0)
+	for {
+		select {
+		case <-p.stopCh:
+			return
+		case job, ok := <-p.preCommitCh:
+			if !ok {
+				return
+			}
+			pending[job.Seq] = job
+			for {
+				j, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				next++
+				select {
+				case p.commitCh <- j:
+				case <-p.stopCh:
+					return
+				}
+			}
+		}
+	}
+}
+
+// Submit 把一个区块推入流水线第一阶段，阻塞直到该区块流转完成或失败(调用方可自行加超时)，
+// 队列满时 Submit 本身会阻塞在向 syntacticCh 的写入上，天然形成对上游拉块的背压。
+// ctx 会原样透传给 Validator 的每一个阶段，最终被 Commit 阶段用来调用矿工的真实上下文。
+// 每次调用按序分配一个单调递增的 Seq，保证即使多个区块同时在流水线里跑，
+// Commit 阶段也严格按提交顺序落盘
+func (p *Pipeline) Submit(ctx interface{}, block interface{}) error {
+	seq := atomic.AddUint64(&p.nextSeq, 1) - 1
+	job := &Job{Seq: seq, Ctx: ctx, Block: block, Result: make(chan error, 1)}
+	select {
+	case p.syntacticCh <- job:
+	case <-p.stopCh:
+		return fmt.Errorf("committer: pipeline stopped")
+	}
+	return <-job.Result
+}
+
+// Stats 返回四个阶段的实时统计快照
+func (p *Pipeline) Stats() Stats {
+	return p.stats
+}
+
+// Stop 通知所有 worker 退出并等待其结束
+func (p *Pipeline) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopCh)
+	})
+	p.wg.Wait()
+}
@@ -0,0 +1,175 @@
+package committer
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeValidator struct {
+	rejectAtMVCC map[int]bool
+}
+
+func (f *fakeValidator) ValidateSyntactic(ctx, block interface{}) error { return nil }
+func (f *fakeValidator) ValidatePolicy(ctx, block interface{}) error    { return nil }
+func (f *fakeValidator) ValidateMVCC(ctx, block interface{}) error {
+	if f.rejectAtMVCC[block.(int)] {
+		return fmt.Errorf("conflicting read version")
+	}
+	return nil
+}
+func (f *fakeValidator) Commit(ctx, block interface{}) error { return nil }
+
+func TestPipelineCommitsValidBlocks(t *testing.T) {
+	p, err := New(Config{SyntacticWorkers: 2, PolicyWorkers: 2, MVCCWorkers: 2, CommitWorkers: 1, QueueSize: 4},
+		&fakeValidator{rejectAtMVCC: map[int]bool{}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer p.Stop()
+
+	for i := 0; i < 10; i++ {
+		if err := p.Submit(nil, i); err != nil {
+			t.Fatalf("Submit(%d) unexpected error: %v", i, err)
+		}
+	}
+
+	stats := p.Stats()
+	if stats.Commit.Processed != 10 {
+		t.Fatalf("expected 10 commits, got %d", stats.Commit.Processed)
+	}
+}
+
+func TestPipelineRejectsMVCCConflict(t *testing.T) {
+	p, err := New(DefaultConfig(), &fakeValidator{rejectAtMVCC: map[int]bool{2: true}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer p.Stop()
+
+	if err := p.Submit(nil, 1); err != nil {
+		t.Fatalf("Submit(1) unexpected error: %v", err)
+	}
+	if err := p.Submit(nil, 2); err == nil {
+		t.Fatalf("expected MVCC conflict error for block 2")
+	}
+
+	stats := p.Stats()
+	if stats.MVCC.Failed != 1 {
+		t.Fatalf("expected 1 failed MVCC validation, got %d", stats.MVCC.Failed)
+	}
+	if stats.Commit.Processed != 1 {
+		t.Fatalf("expected only the non-conflicting block to reach commit, got %d", stats.Commit.Processed)
+	}
+}
+
+// TestPipelineSequencerOrdersOutOfOrderCompletions 直接驱动 runSequencer，绕开真正的
+// Commit worker，验证乱序到达 preCommitCh 的任务会被按 Seq 严格递增的顺序转发到 commitCh
+func TestPipelineSequencerOrdersOutOfOrderCompletions(t *testing.T) {
+	p := &Pipeline{
+		preCommitCh: make(chan *Job, 8),
+		commitCh:    make(chan *Job, 8),
+		stopCh:      make(chan struct{}),
+	}
+	p.wg.Add(1)
+	go p.runSequencer()
+	defer func() {
+		close(p.stopCh)
+		p.wg.Wait()
+	}()
+
+	jobs := []*Job{
+		{Seq: 2, Block: 2, Result: make(chan error, 1)},
+		{Seq: 0, Block: 0, Result: make(chan error, 1)},
+		{Seq: 1, Block: 1, Result: make(chan error, 1)},
+	}
+	for _, j := range jobs {
+		p.preCommitCh <- j
+	}
+
+	for want := uint64(0); want < 3; want++ {
+		select {
+		case got := <-p.commitCh:
+			if got.Seq != want {
+				t.Fatalf("expected seq %d to reach commitCh next, got %d", want, got.Seq)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for seq %d", want)
+		}
+	}
+}
+
+// orderRecordingValidator 的 MVCC 阶段可以故意让先提交的块多等一会儿，制造乱序完成
+// 校验的场景，Commit 阶段记录真正落地的顺序
+type orderRecordingValidator struct {
+	mvccDelay func(block int) time.Duration
+	onCommit  func(block int)
+}
+
+func (v *orderRecordingValidator) ValidateSyntactic(ctx, block interface{}) error { return nil }
+func (v *orderRecordingValidator) ValidatePolicy(ctx, block interface{}) error    { return nil }
+func (v *orderRecordingValidator) ValidateMVCC(ctx, block interface{}) error {
+	if v.mvccDelay != nil {
+		time.Sleep(v.mvccDelay(block.(int)))
+	}
+	return nil
+}
+func (v *orderRecordingValidator) Commit(ctx, block interface{}) error {
+	if v.onCommit != nil {
+		v.onCommit(block.(int))
+	}
+	return nil
+}
+
+// TestPipelineConcurrentBlocksCommitInSubmitOrder 并发提交多个区块，让它们在流水线里
+// 真正重叠在途，MVCC 阶段刻意让先提交的块算得更慢，检验即便校验阶段乱序完成，
+// Commit 阶段依然严格按提交顺序落地
+func TestPipelineConcurrentBlocksCommitInSubmitOrder(t *testing.T) {
+	const n = 6
+
+	var mu sync.Mutex
+	var commitOrder []int
+	v := &orderRecordingValidator{
+		mvccDelay: func(block int) time.Duration {
+			return time.Duration(n-block) * 5 * time.Millisecond
+		},
+		onCommit: func(block int) {
+			mu.Lock()
+			commitOrder = append(commitOrder, block)
+			mu.Unlock()
+		},
+	}
+
+	p, err := New(Config{SyntacticWorkers: 4, PolicyWorkers: 4, MVCCWorkers: 4, CommitWorkers: 1, QueueSize: n}, v)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer p.Stop()
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(block int) {
+			defer wg.Done()
+			if err := p.Submit(nil, block); err != nil {
+				t.Errorf("Submit(%d) unexpected error: %v", block, err)
+			}
+		}(i)
+		// 错开每个 goroutine 真正调用 Submit(分配 Seq)的时机，让 Seq 顺序可预期，
+		// 同时仍然保证前面提交的区块还没提交完成、多个任务确实在流水线里同时在途
+		time.Sleep(2 * time.Millisecond)
+	}
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(commitOrder) != n {
+		t.Fatalf("expected %d commits, got %d: %v", n, len(commitOrder), commitOrder)
+	}
+	for i, block := range commitOrder {
+		if block != i {
+			t.Fatalf("expected blocks to commit in submit order, got %v", commitOrder)
+		}
+	}
+}
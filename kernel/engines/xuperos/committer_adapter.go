@@ -0,0 +1,145 @@
+package xuperos
+
+import (
+	"bytes"
+	"fmt"
+
+	lpb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	xctx "github.com/xuperchain/xupercore/kernel/common/xcontext"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+// chainValidator 把 Chain 现有的依赖(合约沙盒、状态机、矿工)适配成 committer.Validator，
+// 对应 Fabric 风格的 endorser/committer 四个阶段
+type chainValidator struct {
+	chain *Chain
+}
+
+// ValidateSyntactic 做语法/签名层面的校验：区块、交易结构是否完整
+func (v *chainValidator) ValidateSyntactic(ctxIface interface{}, blockIface interface{}) error {
+	block := blockIface.(*lpb.InternalBlock)
+	if len(block.GetBlockid()) == 0 {
+		return fmt.Errorf("empty blockid")
+	}
+	if len(block.GetSign()) == 0 {
+		return fmt.Errorf("empty block signature")
+	}
+	for _, tx := range block.GetTransactions() {
+		if len(tx.GetTxid()) == 0 {
+			return fmt.Errorf("tx with empty txid in block %x", block.GetBlockid())
+		}
+	}
+	return nil
+}
+
+// ValidatePolicy 是 VSCC 的等价物：按交易声明的 ContractRequests 在只读沙盒里重放一遍
+// 合约调用(与 PreExec 走同一条路径)，要求重放产生的读集/写集与交易自带的
+// TxInputsExt/TxOutputsExt 逐项一致 —— 任何背书节点和记账节点用同样的输入重放出不同的
+// 输出，都说明这笔交易的执行结果不可信，整个区块都要被拒绝
+func (v *chainValidator) ValidatePolicy(ctxIface interface{}, blockIface interface{}) error {
+	ctx := ctxIface.(xctx.XContext)
+	block := blockIface.(*lpb.InternalBlock)
+	for _, tx := range block.GetTransactions() {
+		if len(tx.GetContractRequests()) == 0 {
+			continue
+		}
+		resp, err := v.chain.PreExec(ctx, tx.GetContractRequests(), tx.GetInitiator(), tx.GetAuthRequire())
+		if err != nil {
+			return fmt.Errorf("tx %x replay failed policy validation: %v", tx.GetTxid(), err)
+		}
+		if !sameTxInputsExt(resp.GetInputs(), tx.GetTxInputsExt()) {
+			return fmt.Errorf("tx %x declared read set does not match replayed execution", tx.GetTxid())
+		}
+		if !sameTxOutputsExt(resp.GetOutputs(), tx.GetTxOutputsExt()) {
+			return fmt.Errorf("tx %x declared write set does not match replayed execution", tx.GetTxid())
+		}
+	}
+	return nil
+}
+
+// ValidateMVCC 把每笔交易声明读集里引用的版本(RefTxid 指向的那笔交易在 RefOffset 处
+// 写下的值)与该 key 当前在 xmodel 里的实际值逐项比对，只要有一项对不上，说明该 key 在
+// 这笔交易构造之后已经被其他已提交交易改写过，当前这笔交易建立在过期状态之上，存在写冲突
+func (v *chainValidator) ValidateMVCC(ctxIface interface{}, blockIface interface{}) error {
+	block := blockIface.(*lpb.InternalBlock)
+	reader := v.chain.ctx.State.CreateXMReader()
+	for _, tx := range block.GetTransactions() {
+		for _, in := range tx.GetTxInputsExt() {
+			declared, err := v.declaredValue(in)
+			if err != nil {
+				return fmt.Errorf("tx %x resolve declared version of %s/%s failed: %v", tx.GetTxid(), in.GetBucket(), in.GetKey(), err)
+			}
+
+			cur, err := reader.Get(string(in.GetBucket()), in.GetKey())
+			if err != nil {
+				return fmt.Errorf("tx %x query current value of %s/%s failed: %v", tx.GetTxid(), in.GetBucket(), in.GetKey(), err)
+			}
+			if !bytes.Equal(cur, declared) {
+				return fmt.Errorf("tx %x read version conflict on %s/%s", tx.GetTxid(), in.GetBucket(), in.GetKey())
+			}
+		}
+	}
+	return nil
+}
+
+// declaredValue 解出某条声明读集在构造时看到的值：RefTxid 为空表示读的是初始状态(nil)，
+// 否则去 RefTxid 指向的交易的 TxOutputsExt[RefOffset] 里取当时写下的值
+func (v *chainValidator) declaredValue(in *protos.TxInputExt) ([]byte, error) {
+	if len(in.GetRefTxid()) == 0 {
+		return nil, nil
+	}
+	refTx, _, err := v.chain.ctx.State.QueryTx(in.GetRefTxid())
+	if err != nil {
+		return nil, err
+	}
+	if refTx == nil {
+		return nil, nil
+	}
+	offset := int(in.GetRefOffset())
+	outputs := refTx.GetTxOutputsExt()
+	if offset < 0 || offset >= len(outputs) {
+		return nil, fmt.Errorf("ref offset %d out of range for tx %x", offset, in.GetRefTxid())
+	}
+	return outputs[offset].GetValue(), nil
+}
+
+// Commit 把通过前三阶段校验的区块交给矿工完成最终的账本/状态机提交，并触发私有数据补拉。
+// ctx 是 Chain.ProcBlock 收到的同一个调用上下文，由 Pipeline 透传到这里，而不是零值
+func (v *chainValidator) Commit(ctxIface interface{}, blockIface interface{}) error {
+	ctx := ctxIface.(xctx.XContext)
+	block := blockIface.(*lpb.InternalBlock)
+	if err := v.chain.miner.ProcBlock(ctx, block); err != nil {
+		return err
+	}
+	v.chain.reconcilePrivateData(block)
+	return nil
+}
+
+func sameTxInputsExt(a, b []*protos.TxInputExt) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i].GetBucket(), b[i].GetBucket()) ||
+			!bytes.Equal(a[i].GetKey(), b[i].GetKey()) ||
+			!bytes.Equal(a[i].GetRefTxid(), b[i].GetRefTxid()) ||
+			a[i].GetRefOffset() != b[i].GetRefOffset() {
+			return false
+		}
+	}
+	return true
+}
+
+func sameTxOutputsExt(a, b []*protos.TxOutputExt) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i].GetBucket(), b[i].GetBucket()) ||
+			!bytes.Equal(a[i].GetKey(), b[i].GetKey()) ||
+			!bytes.Equal(a[i].GetValue(), b[i].GetValue()) {
+			return false
+		}
+	}
+	return true
+}
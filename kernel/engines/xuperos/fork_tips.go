@@ -0,0 +1,94 @@
+package xuperos
+
+import (
+	"sync"
+
+	"github.com/xuperchain/xupercore/kernel/engines/xuperos/forkchoice"
+)
+
+// tipCacheSize 控制 tipRegistry 保留的最近链尖数量，足够覆盖正常网络延迟下的竞争分叉深度，
+// 超出部分按登记顺序淘汰，避免无界增长
+const tipCacheSize = 256
+
+// tipRegistry 记录 ProcBlock 观测到的最近若干个链尖，并把它们按父块哈希串成 Parent 链，
+// 近似账本的分叉数据库，供 ForkChoice 的 GHOST/Filecoin 规则沿链回溯权重，
+// 也供 commonAncestorHeight 做真正的最近公共祖先查找
+type tipRegistry struct {
+	mu    sync.Mutex
+	byID  map[string]*forkchoice.ChainTip
+	order [][]byte
+}
+
+func newTipRegistry() *tipRegistry {
+	return &tipRegistry{byID: make(map[string]*forkchoice.ChainTip)}
+}
+
+// record 把一个链尖登记进注册表，parentID 非空且该链尖还没有 Parent 时自动挂上 Parent 指针。
+// 已经登记过的链尖不会被覆盖，避免反复调用 currentChainTip 时把已有的 Parent 链接丢掉
+func (r *tipRegistry) record(tip *forkchoice.ChainTip, parentID []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.byID[string(tip.BlockID)]; ok {
+		if existing.Parent == nil && len(parentID) > 0 {
+			existing.Parent = r.byID[string(parentID)]
+		}
+		return
+	}
+
+	if len(parentID) > 0 {
+		tip.Parent = r.byID[string(parentID)]
+	}
+	r.byID[string(tip.BlockID)] = tip
+	r.order = append(r.order, tip.BlockID)
+	if len(r.order) > tipCacheSize {
+		evict := r.order[0]
+		r.order = r.order[1:]
+		delete(r.byID, string(evict))
+	}
+}
+
+// candidates 返回注册表里所有仍被保留的链尖，供 ForkChoice.Select 在真实分叉集合上挑选主链
+func (r *tipRegistry) candidates() []*forkchoice.ChainTip {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]*forkchoice.ChainTip, 0, len(r.byID))
+	for _, tip := range r.byID {
+		out = append(out, tip)
+	}
+	return out
+}
+
+// isAncestor 判断 ancestor 是否在 tip 的 Parent 链上(含 tip 自身)。正常顺序出块时，
+// 新链尖的 Parent 链必然经过当前链尖，这种情况不构成重组；只有当前链尖被真正甩出
+// Parent 链(分叉切换)时才是一次重组
+func isAncestor(ancestor, tip *forkchoice.ChainTip) bool {
+	if ancestor == nil {
+		return false
+	}
+	for t := tip; t != nil; t = t.Parent {
+		if string(t.BlockID) == string(ancestor.BlockID) {
+			return true
+		}
+	}
+	return false
+}
+
+// commonAncestorHeight 沿 a、b 的 Parent 指针向上走，返回二者第一个相同 BlockID 祖先的高度；
+// 注册表里还没有积累出公共祖先时(例如刚启动、Parent 链不完整)退化为两者高度的较小值
+func commonAncestorHeight(a, b *forkchoice.ChainTip) int64 {
+	ancestors := make(map[string]int64)
+	for t := a; t != nil; t = t.Parent {
+		ancestors[string(t.BlockID)] = t.Height
+	}
+	for t := b; t != nil; t = t.Parent {
+		if h, ok := ancestors[string(t.BlockID)]; ok {
+			return h
+		}
+	}
+	if a.Height < b.Height {
+		return a.Height
+	}
+	return b.Height
+}
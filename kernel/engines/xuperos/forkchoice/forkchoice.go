@@ -0,0 +1,39 @@
+// Package forkchoice 把“选哪个分叉作为主链”从 miner.ProcBlock 里的硬编码逻辑
+// 抽成可插拔策略，不同 bcName 可以在创世配置里选择不同的规则。
+package forkchoice
+
+// ChainTip 描述一个候选分叉的链尖，Weight 由具体 ForkChoice 实现自行定义口径
+// (高度+共识权重 / GHOST 累计叔块权重 / Filecoin 式累计 ticket 权重)
+type ChainTip struct {
+	BlockID []byte
+	Height  int64
+	// ConsensusWeight 是共识模块给出的该块权重(例如 PoW 难度、PoS 票数)
+	ConsensusWeight uint64
+	// UncleWeight 是 GHOST 规则下该块自身带来的叔块权重，GhostRule 沿 Parent 链累加
+	UncleWeight uint64
+	// TicketWeight 是 Filecoin 式规则下该块按信标轮次派生的 ticket 权重，HeaviestTipsetRule 沿 Parent 链累加
+	TicketWeight uint64
+	// Parent 指向父分叉链尖，Nil 表示创世块
+	Parent *ChainTip
+}
+
+// ForkChoice 定义链在多个候选分叉间选择主链的规则
+type ForkChoice interface {
+	// Compare 返回 a 相对 b 的优劣：>0 表示 a 更优，<0 表示 b 更优，0 表示打平
+	Compare(a, b *ChainTip) int
+	// Select 从候选集合里选出应当成为新主链尖的 ChainTip
+	Select(candidates []*ChainTip) *ChainTip
+	// OnNewBlock 在一个新块被接纳为候选分叉的一部分时回调，供权重实现增量更新状态
+	OnNewBlock(tip *ChainTip)
+}
+
+// Select 是各实现可复用的通用选择逻辑：线性扫描 candidates，用 Compare 挑出最优者
+func Select(fc ForkChoice, candidates []*ChainTip) *ChainTip {
+	var best *ChainTip
+	for _, c := range candidates {
+		if best == nil || fc.Compare(c, best) > 0 {
+			best = c
+		}
+	}
+	return best
+}
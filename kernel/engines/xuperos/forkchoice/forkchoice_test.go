@@ -0,0 +1,49 @@
+package forkchoice
+
+import "testing"
+
+func TestHeightWeightRulePrefersHeight(t *testing.T) {
+	rule := NewHeightWeightRule()
+	low := &ChainTip{Height: 5, ConsensusWeight: 100}
+	high := &ChainTip{Height: 6, ConsensusWeight: 1}
+
+	got := rule.Select([]*ChainTip{low, high})
+	if got != high {
+		t.Fatalf("expected higher block to win")
+	}
+}
+
+func TestGhostRuleCountsUncleWeight(t *testing.T) {
+	rule := NewGhostRule()
+
+	genesis := &ChainTip{BlockID: []byte("g"), Height: 0}
+	tipA := &ChainTip{BlockID: []byte("a"), Height: 1, ConsensusWeight: 1, Parent: genesis}
+	tipB := &ChainTip{BlockID: []byte("b"), Height: 1, ConsensusWeight: 1, Parent: genesis, UncleWeight: 5}
+
+	rule.OnNewBlock(tipA)
+	rule.OnNewBlock(tipB)
+
+	got := rule.Select([]*ChainTip{tipA, tipB})
+	if got != tipB {
+		t.Fatalf("expected tip with heavier uncle weight to win")
+	}
+}
+
+func TestHeaviestTipsetRuleSumsTicketWeight(t *testing.T) {
+	rule := NewHeaviestTipsetRule()
+
+	genesis := &ChainTip{Height: 0}
+	light := &ChainTip{Height: 1, TicketWeight: 2, Parent: genesis}
+	heavy := &ChainTip{Height: 1, TicketWeight: 9, Parent: genesis}
+
+	got := rule.Select([]*ChainTip{light, heavy})
+	if got != heavy {
+		t.Fatalf("expected tipset with heavier accumulated ticket weight to win")
+	}
+}
+
+func TestNewUnknownRule(t *testing.T) {
+	if _, err := New("not-a-rule"); err == nil {
+		t.Fatalf("expected error for unknown rule name")
+	}
+}
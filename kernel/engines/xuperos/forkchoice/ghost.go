@@ -0,0 +1,45 @@
+package forkchoice
+
+// GhostRule 实现 GHOST(Greedy Heaviest Observed Sub-Tree)式分叉选择：
+// 被丢弃分叉(叔块)贡献的权重仍然计入其最近公共祖先所在分叉的总权重
+type GhostRule struct {
+	// uncleWeights 按 BlockID 的字符串形式记录每个分叉自身新增的叔块权重
+	uncleWeights map[string]uint64
+}
+
+// NewGhostRule 创建一个 GhostRule 实例
+func NewGhostRule() *GhostRule {
+	return &GhostRule{uncleWeights: make(map[string]uint64)}
+}
+
+// totalWeight 沿父链累加 ConsensusWeight 与已归集的叔块权重
+func (r *GhostRule) totalWeight(tip *ChainTip) uint64 {
+	var total uint64
+	for t := tip; t != nil; t = t.Parent {
+		total += t.ConsensusWeight + r.uncleWeights[string(t.BlockID)]
+	}
+	return total
+}
+
+func (r *GhostRule) Compare(a, b *ChainTip) int {
+	wa, wb := r.totalWeight(a), r.totalWeight(b)
+	if wa == wb {
+		return int(a.Height - b.Height)
+	}
+	if wa > wb {
+		return 1
+	}
+	return -1
+}
+
+func (r *GhostRule) Select(candidates []*ChainTip) *ChainTip {
+	return Select(r, candidates)
+}
+
+// OnNewBlock 把新块的 UncleWeight 归集到它所在分叉上，后续 totalWeight 会沿父链累加
+func (r *GhostRule) OnNewBlock(tip *ChainTip) {
+	if tip == nil {
+		return
+	}
+	r.uncleWeights[string(tip.BlockID)] += tip.UncleWeight
+}
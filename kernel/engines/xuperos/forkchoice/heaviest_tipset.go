@@ -0,0 +1,37 @@
+package forkchoice
+
+// HeaviestTipsetRule 实现 Filecoin 式“最重 tipset”规则：每个分叉的权重是沿链
+// 累加的 ticket 权重(由 beacon.BeaconEntry 派生)，而不是单纯的块数或共识权重
+type HeaviestTipsetRule struct{}
+
+// NewHeaviestTipsetRule 创建 Filecoin 式分叉选择规则
+func NewHeaviestTipsetRule() *HeaviestTipsetRule {
+	return &HeaviestTipsetRule{}
+}
+
+func (r *HeaviestTipsetRule) totalTicketWeight(tip *ChainTip) uint64 {
+	var total uint64
+	for t := tip; t != nil; t = t.Parent {
+		total += t.TicketWeight
+	}
+	return total
+}
+
+func (r *HeaviestTipsetRule) Compare(a, b *ChainTip) int {
+	wa, wb := r.totalTicketWeight(a), r.totalTicketWeight(b)
+	if wa == wb {
+		return int(a.Height - b.Height)
+	}
+	if wa > wb {
+		return 1
+	}
+	return -1
+}
+
+func (r *HeaviestTipsetRule) Select(candidates []*ChainTip) *ChainTip {
+	return Select(r, candidates)
+}
+
+func (r *HeaviestTipsetRule) OnNewBlock(tip *ChainTip) {
+	// 每块的 ticket 权重已经携带在 ChainTip.TicketWeight 上，Compare 时随父链遍历求和
+}
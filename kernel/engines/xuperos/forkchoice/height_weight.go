@@ -0,0 +1,31 @@
+package forkchoice
+
+// HeightWeightRule 是当前默认的分叉选择规则：先比高度，高度相同再比共识权重，
+// 对应 miner.ProcBlock 原先的隐式行为
+type HeightWeightRule struct{}
+
+// NewHeightWeightRule 创建默认的“最长链+共识权重”规则
+func NewHeightWeightRule() *HeightWeightRule {
+	return &HeightWeightRule{}
+}
+
+func (r *HeightWeightRule) Compare(a, b *ChainTip) int {
+	if a.Height != b.Height {
+		return int(a.Height - b.Height)
+	}
+	if a.ConsensusWeight != b.ConsensusWeight {
+		if a.ConsensusWeight > b.ConsensusWeight {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
+
+func (r *HeightWeightRule) Select(candidates []*ChainTip) *ChainTip {
+	return Select(r, candidates)
+}
+
+func (r *HeightWeightRule) OnNewBlock(tip *ChainTip) {
+	// 高度+共识权重规则无需维护额外状态
+}
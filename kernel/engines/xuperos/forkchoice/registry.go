@@ -0,0 +1,23 @@
+package forkchoice
+
+import "fmt"
+
+const (
+	RuleHeightWeight   = "height-weight"
+	RuleGhost          = "ghost"
+	RuleHeaviestTipset = "heaviest-tipset"
+)
+
+// New 按创世配置里声明的规则名创建对应的 ForkChoice 实现，名字为空时使用默认规则
+func New(name string) (ForkChoice, error) {
+	switch name {
+	case "", RuleHeightWeight:
+		return NewHeightWeightRule(), nil
+	case RuleGhost:
+		return NewGhostRule(), nil
+	case RuleHeaviestTipset:
+		return NewHeaviestTipsetRule(), nil
+	default:
+		return nil, fmt.Errorf("forkchoice: unknown rule %q", name)
+	}
+}
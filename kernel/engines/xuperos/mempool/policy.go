@@ -0,0 +1,198 @@
+// Package mempool 提供可插拔的交易池准入策略，在 Chain.SubmitTx 校验/提交交易前
+// 对交易做统一的限流、限额和黑名单过滤。
+package mempool
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	lpb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+)
+
+// RejectReason 标识一笔交易被拒绝准入的具体原因，用于 PolicyRejected 指标打标签
+type RejectReason string
+
+const (
+	RejectNone            RejectReason = ""
+	RejectTxTooLarge      RejectReason = "TxTooLarge"
+	RejectBlockTxCountCap RejectReason = "BlockTxCountCap"
+	RejectRateLimited     RejectReason = "RateLimited"
+	RejectBlockedAccount  RejectReason = "BlockedAccount"
+	RejectBlockedContract RejectReason = "BlockedContract"
+	RejectGasPriceTooLow  RejectReason = "GasPriceTooLow"
+)
+
+// Policy 是交易池准入策略的统一接口，LoadChain 按 bcName 加载具体实现并可热更新
+type Policy interface {
+	// Admit 在 Chain.SubmitTx 调用 State.VerifyTx 之前执行，返回非 RejectNone 时交易应被拒绝
+	Admit(tx *lpb.Transaction) RejectReason
+}
+
+// Config 对应 mempool_policy.yaml 的内容
+type Config struct {
+	// MaxTxSizeBytes 单笔交易的最大字节数，<=0 表示不限制
+	MaxTxSizeBytes int `yaml:"maxTxSizeBytes"`
+	// MaxTxCountPerBlock 单个区块允许打包的最大交易数，<=0 表示不限制
+	MaxTxCountPerBlock int `yaml:"maxTxCountPerBlock"`
+	// SenderRateLimit 单个发送账户每秒允许提交的交易数，<=0 表示不限制
+	SenderRateLimit int `yaml:"senderRateLimit"`
+	// MinGasPrice 交易 gas price 不得低于该值，<=0 表示不限制
+	MinGasPrice int64 `yaml:"minGasPrice"`
+	// HighPriorityContracts 命中的合约调用跳过 gas price 下限检查
+	HighPriorityContracts []string `yaml:"highPriorityContracts"`
+	// BlockedAccounts 禁止作为 Initiator 提交交易的账户地址
+	BlockedAccounts []string `yaml:"blockedAccounts"`
+	// BlockedContracts 禁止被调用的合约名
+	BlockedContracts []string `yaml:"blockedContracts"`
+}
+
+// SimplePolicy 是 Policy 的默认实现，按 Config 中声明的规则逐项校验
+type SimplePolicy struct {
+	mu  sync.RWMutex
+	cfg Config
+
+	highPriorityContracts map[string]bool
+	blockedAccounts       map[string]bool
+	blockedContracts      map[string]bool
+
+	rateMu    sync.Mutex
+	lastSeen  map[string]time.Time
+	senderHit map[string]int
+
+	// pendingMu/pendingCount 近似统计已经被 Admit 放行、但还没有离开交易池(打包进
+	// 区块，或者在 Admit 之后又被后续校验/提交环节拒绝)的交易数，用来实现
+	// MaxTxCountPerBlock：Admit 本身只看得到单笔交易，真正的计数和回收要靠调用方在
+	// 区块提交、或者交易被后续步骤拒绝时调用 Release 配合完成
+	pendingMu    sync.Mutex
+	pendingCount int
+}
+
+// NewSimplePolicy 按给定配置创建策略实例
+func NewSimplePolicy(cfg Config) *SimplePolicy {
+	p := &SimplePolicy{
+		lastSeen:  make(map[string]time.Time),
+		senderHit: make(map[string]int),
+	}
+	p.Reload(cfg)
+	return p
+}
+
+// Reload 原子替换策略配置，用于 Chain.ReloadMempoolPolicy 热更新
+func (p *SimplePolicy) Reload(cfg Config) {
+	highPriority := toSet(cfg.HighPriorityContracts)
+	blockedAccounts := toSet(cfg.BlockedAccounts)
+	blockedContracts := toSet(cfg.BlockedContracts)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cfg = cfg
+	p.highPriorityContracts = highPriority
+	p.blockedAccounts = blockedAccounts
+	p.blockedContracts = blockedContracts
+}
+
+func toSet(items []string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		set[item] = true
+	}
+	return set
+}
+
+// Admit 依次校验交易大小、发送方黑名单、合约黑名单、发送方限流、gas price 下限和待打包交易数上限
+func (p *SimplePolicy) Admit(tx *lpb.Transaction) RejectReason {
+	p.mu.RLock()
+	cfg := p.cfg
+	blockedAccounts := p.blockedAccounts
+	blockedContracts := p.blockedContracts
+	highPriority := p.highPriorityContracts
+	p.mu.RUnlock()
+
+	if cfg.MaxTxSizeBytes > 0 && tx.Size() > int32(cfg.MaxTxSizeBytes) {
+		return RejectTxTooLarge
+	}
+
+	if blockedAccounts[string(tx.GetInitiator())] {
+		return RejectBlockedAccount
+	}
+
+	skipGasFloor := false
+	for _, req := range tx.GetContractRequests() {
+		if blockedContracts[req.GetContractName()] {
+			return RejectBlockedContract
+		}
+		if highPriority[req.GetContractName()] {
+			skipGasFloor = true
+		}
+	}
+
+	if reason := p.checkRateLimit(string(tx.GetInitiator())); reason != RejectNone {
+		return reason
+	}
+
+	if !skipGasFloor && cfg.MinGasPrice > 0 && tx.GetGasPrice() < cfg.MinGasPrice {
+		return RejectGasPriceTooLow
+	}
+
+	if cfg.MaxTxCountPerBlock > 0 {
+		p.pendingMu.Lock()
+		if p.pendingCount >= cfg.MaxTxCountPerBlock {
+			p.pendingMu.Unlock()
+			return RejectBlockTxCountCap
+		}
+		p.pendingCount++
+		p.pendingMu.Unlock()
+	}
+
+	return RejectNone
+}
+
+// Release 把 n 笔交易从待打包计数里释放，调用方在区块提交后按区块实际打包的交易数
+// 回收计数，或者某笔交易通过 Admit 之后又被后续的验签/提交步骤拒绝、从未真正进入
+// 交易池时单笔回收，避免计数只增不减导致后续交易被误判为超限
+func (p *SimplePolicy) Release(n int) {
+	if n <= 0 {
+		return
+	}
+	p.pendingMu.Lock()
+	p.pendingCount -= n
+	if p.pendingCount < 0 {
+		p.pendingCount = 0
+	}
+	p.pendingMu.Unlock()
+}
+
+func (p *SimplePolicy) checkRateLimit(sender string) RejectReason {
+	p.mu.RLock()
+	limit := p.cfg.SenderRateLimit
+	p.mu.RUnlock()
+	if limit <= 0 || sender == "" {
+		return RejectNone
+	}
+
+	now := time.Now()
+	p.rateMu.Lock()
+	defer p.rateMu.Unlock()
+
+	if last, ok := p.lastSeen[sender]; !ok || now.Sub(last) >= time.Second {
+		p.lastSeen[sender] = now
+		p.senderHit[sender] = 1
+		return RejectNone
+	}
+
+	p.senderHit[sender]++
+	if p.senderHit[sender] > limit {
+		return RejectRateLimited
+	}
+	return RejectNone
+}
+
+// LoadConfigFile 从 mempool_policy.yaml 加载配置，文件不存在时返回零值配置(即不限制)
+func LoadConfigFile(path string) (Config, error) {
+	cfg, err := loadYAMLConfig(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("load mempool policy config failed: %v", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,93 @@
+package mempool
+
+import (
+	"testing"
+
+	lpb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+func TestSimplePolicyBlockedAccount(t *testing.T) {
+	p := NewSimplePolicy(Config{
+		BlockedAccounts: []string{"bad-account"},
+	})
+
+	tx := &lpb.Transaction{Initiator: "bad-account"}
+	if reason := p.Admit(tx); reason != RejectBlockedAccount {
+		t.Fatalf("expected RejectBlockedAccount, got %q", reason)
+	}
+}
+
+func TestSimplePolicyBlockedContractAndHighPriority(t *testing.T) {
+	p := NewSimplePolicy(Config{
+		MinGasPrice:           100,
+		BlockedContracts:      []string{"evil"},
+		HighPriorityContracts: []string{"vip"},
+	})
+
+	blocked := &lpb.Transaction{
+		Initiator:        "alice",
+		ContractRequests: []*protos.InvokeRequest{{ContractName: "evil"}},
+	}
+	if reason := p.Admit(blocked); reason != RejectBlockedContract {
+		t.Fatalf("expected RejectBlockedContract, got %q", reason)
+	}
+
+	lowGas := &lpb.Transaction{Initiator: "alice", GasPrice: 1}
+	if reason := p.Admit(lowGas); reason != RejectGasPriceTooLow {
+		t.Fatalf("expected RejectGasPriceTooLow, got %q", reason)
+	}
+
+	vip := &lpb.Transaction{
+		Initiator:        "alice",
+		GasPrice:         1,
+		ContractRequests: []*protos.InvokeRequest{{ContractName: "vip"}},
+	}
+	if reason := p.Admit(vip); reason != RejectNone {
+		t.Fatalf("expected high priority contract to bypass gas floor, got %q", reason)
+	}
+}
+
+func TestSimplePolicyReload(t *testing.T) {
+	p := NewSimplePolicy(Config{MaxTxSizeBytes: 0})
+	p.Reload(Config{BlockedAccounts: []string{"bob"}})
+
+	tx := &lpb.Transaction{Initiator: "bob"}
+	if reason := p.Admit(tx); reason != RejectBlockedAccount {
+		t.Fatalf("expected reload to take effect, got %q", reason)
+	}
+}
+
+func TestSimplePolicyMaxTxCountPerBlock(t *testing.T) {
+	p := NewSimplePolicy(Config{MaxTxCountPerBlock: 2})
+
+	tx := &lpb.Transaction{Initiator: "alice"}
+	if reason := p.Admit(tx); reason != RejectNone {
+		t.Fatalf("expected 1st tx to be admitted, got %q", reason)
+	}
+	if reason := p.Admit(tx); reason != RejectNone {
+		t.Fatalf("expected 2nd tx to be admitted, got %q", reason)
+	}
+	if reason := p.Admit(tx); reason != RejectBlockTxCountCap {
+		t.Fatalf("expected 3rd tx to be rejected with RejectBlockTxCountCap, got %q", reason)
+	}
+
+	// 释放一个名额(对应一笔交易打包提交或者在 Admit 之后被拒绝)，应该能再放行一笔
+	p.Release(1)
+	if reason := p.Admit(tx); reason != RejectNone {
+		t.Fatalf("expected tx to be admitted after Release, got %q", reason)
+	}
+
+	// Release 不应该把计数减到负数导致之后的 Admit 凭空多出名额
+	p.Release(100)
+	p.Release(100)
+	if reason := p.Admit(tx); reason != RejectNone {
+		t.Fatalf("expected 1st tx after over-release to be admitted, got %q", reason)
+	}
+	if reason := p.Admit(tx); reason != RejectNone {
+		t.Fatalf("expected 2nd tx after over-release to be admitted, got %q", reason)
+	}
+	if reason := p.Admit(tx); reason != RejectBlockTxCountCap {
+		t.Fatalf("expected cap to still apply after over-release, got %q", reason)
+	}
+}
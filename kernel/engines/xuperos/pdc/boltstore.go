@@ -0,0 +1,105 @@
+package pdc
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// BoltStore 是 Store 接口基于 BoltDB 的实现，每个 collection 对应一个独立的 bucket，
+// bucket 内以 txid 作为 key，value 是 blockHeight(8字节大端) + payload —— height 只是
+// 写入时的参考值，存在 value 里供 Range 扫描用，point lookup 不依赖它
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore 打开(或创建) path 处的 BoltDB 文件作为私有数据 side-DB
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("pdc: open boltdb failed: %v", err)
+	}
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+func encodeBoltValue(blockHeight int64, payload []byte) []byte {
+	buf := make([]byte, 8+len(payload))
+	binary.BigEndian.PutUint64(buf[:8], uint64(blockHeight))
+	copy(buf[8:], payload)
+	return buf
+}
+
+func decodeBoltValue(v []byte) (int64, []byte) {
+	if len(v) < 8 {
+		return 0, nil
+	}
+	return int64(binary.BigEndian.Uint64(v[:8])), append([]byte(nil), v[8:]...)
+}
+
+func (s *BoltStore) Put(collection string, blockHeight int64, txid string, payload []byte) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(collection))
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(txid), encodeBoltValue(blockHeight, payload))
+	})
+}
+
+func (s *BoltStore) Get(collection string, txid string) ([]byte, bool, error) {
+	var payload []byte
+	var exist bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(collection))
+		if bucket == nil {
+			return nil
+		}
+		v := bucket.Get([]byte(txid))
+		if v == nil {
+			return nil
+		}
+		exist = true
+		_, payload = decodeBoltValue(v)
+		return nil
+	})
+	return payload, exist, err
+}
+
+// Range 遍历 [startHeight, endHeight] 区间内的所有条目，返回 txid -> payload
+func (s *BoltStore) Range(collection string, startHeight, endHeight int64) (map[string][]byte, error) {
+	result := make(map[string][]byte)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(collection))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			height, payload := decodeBoltValue(v)
+			if height < startHeight || height > endHeight {
+				return nil
+			}
+			result[string(k)] = payload
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Delete 按 txid 删除某个集合中对应的条目(支持 GDPR 式清除明文但不影响链上哈希)
+func (s *BoltStore) Delete(collection string, txid string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(collection))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.Delete([]byte(txid))
+	})
+}
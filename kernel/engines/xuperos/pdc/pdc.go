@@ -0,0 +1,171 @@
+// Package pdc (private data collection) 为合约提供类 Fabric 的保密状态能力:
+// 公开账本只保留 payload 的哈希引用，真实的 key/value 只保存在集合成员本地的
+// side-DB 中，非成员节点依然可以凭哈希校验区块，但看不到明文内容。
+package pdc
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNotMember         = errors.New("pdc: local node is not a member of the collection")
+	ErrUnknownCollection = errors.New("pdc: unknown collection")
+	ErrPayloadMissing    = errors.New("pdc: payload missing locally")
+)
+
+// CollectionConfig 描述一个私有数据集合的成员 ACL
+type CollectionConfig struct {
+	Name    string
+	Members []string
+}
+
+// HashRef 是写到公开账本 rwSet.WSet 里的私有数据引用，代替明文 payload
+type HashRef struct {
+	Collection string
+	Hash       []byte
+}
+
+// HashPayload 计算 payload 的哈希引用，上链部分只保留该值
+func HashPayload(collection string, payload []byte) *HashRef {
+	sum := sha256.Sum256(payload)
+	return &HashRef{Collection: collection, Hash: sum[:]}
+}
+
+// key 是 side-DB 中一条私有数据的定位坐标
+type key struct {
+	collection  string
+	blockHeight int64
+	txid        string
+}
+
+// Store 是私有数据 payload 的本地存储接口，由 BoltDB 实现，测试可替换为内存实现。
+// Get/Delete 只按 txid 定位，不要求调用方知道写入时的 blockHeight —— 一笔交易提交时的
+// 链高度和它最终上链的高度通常不同，point lookup 不应该依赖这个易变的值
+type Store interface {
+	Put(collection string, blockHeight int64, txid string, payload []byte) error
+	Get(collection string, txid string) ([]byte, bool, error)
+	Range(collection string, startHeight, endHeight int64) (map[string][]byte, error)
+	Delete(collection string, txid string) error
+	// Close 释放底层存储持有的文件句柄/锁，随 Chain.Stop 一起调用
+	Close() error
+}
+
+// PeerFetcher 通过既有 P2P 层按需向其他成员拉取缺失的 payload
+type PeerFetcher interface {
+	FetchPrivatePayload(collection, txid string) ([]byte, error)
+}
+
+// PrivateDataManager 管理本节点已知的私有数据集合、本地 side-DB 存储，
+// 以及缺失 payload 的后台补拉
+type PrivateDataManager struct {
+	store       Store
+	fetcher     PeerFetcher
+	nodeAddr    string
+	collections map[string]*CollectionConfig
+}
+
+// NewPrivateDataManager 创建一个 PrivateDataManager，nodeAddr 是本节点地址，
+// 用于判断本节点是否为某个集合的成员
+func NewPrivateDataManager(store Store, fetcher PeerFetcher, nodeAddr string, collections []*CollectionConfig) *PrivateDataManager {
+	m := &PrivateDataManager{
+		store:       store,
+		fetcher:     fetcher,
+		nodeAddr:    nodeAddr,
+		collections: make(map[string]*CollectionConfig, len(collections)),
+	}
+	for _, c := range collections {
+		m.collections[c.Name] = c
+	}
+	return m
+}
+
+// IsMember 判断本节点是否为指定集合的成员
+func (m *PrivateDataManager) IsMember(collection string) bool {
+	c, ok := m.collections[collection]
+	if !ok {
+		return false
+	}
+	for _, addr := range c.Members {
+		if addr == m.nodeAddr {
+			return true
+		}
+	}
+	return false
+}
+
+// StorePayload 在本地成员节点落盘一条私有数据，同时返回上链用的哈希引用
+func (m *PrivateDataManager) StorePayload(collection string, blockHeight int64, txid string, payload []byte) (*HashRef, error) {
+	if _, ok := m.collections[collection]; !ok {
+		return nil, ErrUnknownCollection
+	}
+	if !m.IsMember(collection) {
+		return nil, ErrNotMember
+	}
+	if err := m.store.Put(collection, blockHeight, txid, payload); err != nil {
+		return nil, fmt.Errorf("pdc: store payload failed: %v", err)
+	}
+	return HashPayload(collection, payload), nil
+}
+
+// VerifyHash 校验本地 payload 与上链哈希引用是否一致，供非成员以外的成员节点自检
+func (m *PrivateDataManager) VerifyHash(ref *HashRef, payload []byte) bool {
+	if ref == nil {
+		return false
+	}
+	got := HashPayload(ref.Collection, payload)
+	if len(got.Hash) != len(ref.Hash) {
+		return false
+	}
+	for i := range got.Hash {
+		if got.Hash[i] != ref.Hash[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ReconcileMissing 在 ProcBlock 处理新区块后调用：对本节点是成员但本地缺失 payload 的
+// 交易，在后台 goroutine 里从其余成员拉取一次；拉取失败时静默放弃，不会自动重试
+func (m *PrivateDataManager) ReconcileMissing(collection string, blockHeight int64, txid string) {
+	if !m.IsMember(collection) {
+		return
+	}
+	if _, exist, _ := m.store.Get(collection, txid); exist {
+		return
+	}
+	if m.fetcher == nil {
+		return
+	}
+
+	go func() {
+		payload, err := m.fetcher.FetchPrivatePayload(collection, txid)
+		if err != nil {
+			return
+		}
+		_ = m.store.Put(collection, blockHeight, txid, payload)
+	}()
+}
+
+// GetPrivateDataByRange 读取某个集合在 [start, end] 区块高度范围内落盘的 payload，
+// 供读 API 使用；非成员节点调用会收到 ErrNotMember
+func (m *PrivateDataManager) GetPrivateDataByRange(collection string, start, end int64) (map[string][]byte, error) {
+	if !m.IsMember(collection) {
+		return nil, ErrNotMember
+	}
+	return m.store.Range(collection, start, end)
+}
+
+// Purge 删除某笔交易在集合内的本地明文 payload，链上哈希引用保持不变，用于 GDPR 式删除
+func (m *PrivateDataManager) Purge(collection string, txid string) error {
+	if !m.IsMember(collection) {
+		return ErrNotMember
+	}
+	return m.store.Delete(collection, txid)
+}
+
+// Close 关闭底层 side-DB，随 Chain.Stop 一起调用，避免 BoltDB 文件句柄/锁泄漏
+func (m *PrivateDataManager) Close() error {
+	return m.store.Close()
+}
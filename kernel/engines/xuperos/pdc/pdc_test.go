@@ -0,0 +1,77 @@
+package pdc
+
+import "testing"
+
+type memStore struct {
+	data map[string][]byte
+}
+
+func newMemStore() *memStore {
+	return &memStore{data: make(map[string][]byte)}
+}
+
+func memKey(collection string, blockHeight int64, txid string) string {
+	return collection + "/" + txid
+}
+
+func (s *memStore) Put(collection string, blockHeight int64, txid string, payload []byte) error {
+	s.data[memKey(collection, blockHeight, txid)] = payload
+	return nil
+}
+
+func (s *memStore) Get(collection string, txid string) ([]byte, bool, error) {
+	v, ok := s.data[memKey(collection, 0, txid)]
+	return v, ok, nil
+}
+
+func (s *memStore) Range(collection string, startHeight, endHeight int64) (map[string][]byte, error) {
+	return nil, nil
+}
+
+func (s *memStore) Delete(collection string, txid string) error {
+	delete(s.data, memKey(collection, 0, txid))
+	return nil
+}
+
+func (s *memStore) Close() error {
+	return nil
+}
+
+func TestPrivateDataManagerMembership(t *testing.T) {
+	store := newMemStore()
+	mgr := NewPrivateDataManager(store, nil, "alice", []*CollectionConfig{
+		{Name: "secret", Members: []string{"alice", "bob"}},
+	})
+
+	if !mgr.IsMember("secret") {
+		t.Fatalf("alice should be a member of secret")
+	}
+	if mgr.IsMember("unknown") {
+		t.Fatalf("unknown collection should report non-member")
+	}
+
+	ref, err := mgr.StorePayload("secret", 10, "tx1", []byte("payload"))
+	if err != nil {
+		t.Fatalf("StorePayload failed: %v", err)
+	}
+	if !mgr.VerifyHash(ref, []byte("payload")) {
+		t.Fatalf("VerifyHash should succeed for matching payload")
+	}
+	if mgr.VerifyHash(ref, []byte("tampered")) {
+		t.Fatalf("VerifyHash should fail for tampered payload")
+	}
+}
+
+func TestPrivateDataManagerNonMemberRejected(t *testing.T) {
+	store := newMemStore()
+	mgr := NewPrivateDataManager(store, nil, "eve", []*CollectionConfig{
+		{Name: "secret", Members: []string{"alice", "bob"}},
+	})
+
+	if _, err := mgr.StorePayload("secret", 10, "tx1", []byte("payload")); err != ErrNotMember {
+		t.Fatalf("expected ErrNotMember, got %v", err)
+	}
+	if _, err := mgr.GetPrivateDataByRange("secret", 0, 100); err != ErrNotMember {
+		t.Fatalf("expected ErrNotMember, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package xuperos
+
+import (
+	"github.com/xuperchain/xupercore/kernel/contract"
+)
+
+// overrideXMReader 在真实的 XMReader 之上叠加 SimulateTx 的合约代码覆盖，
+// 命中覆盖的合约 key 直接返回注入的代码，其余 key 透传给底层 reader
+type overrideXMReader struct {
+	contract.XMReader
+	codeOverrides map[string][]byte
+}
+
+func newOverrideXMReader(reader contract.XMReader, codeOverrides map[string][]byte) contract.XMReader {
+	if len(codeOverrides) == 0 {
+		return reader
+	}
+	return &overrideXMReader{XMReader: reader, codeOverrides: codeOverrides}
+}
+
+func (r *overrideXMReader) Get(bucket string, key []byte) ([]byte, error) {
+	if code, ok := r.codeOverrides[string(key)]; ok {
+		return code, nil
+	}
+	return r.XMReader.Get(bucket, key)
+}
+
+// overrideUTXOReader 在真实的 UTXOReader 之上叠加 SimulateTx 的余额覆盖，
+// 用于 what-if 分析时临时注入某账户的假想余额
+type overrideUTXOReader struct {
+	contract.UTXOReader
+	balanceOverrides map[string]string
+}
+
+func newOverrideUTXOReader(reader contract.UTXOReader, balanceOverrides map[string]string) contract.UTXOReader {
+	if len(balanceOverrides) == 0 {
+		return reader
+	}
+	return &overrideUTXOReader{UTXOReader: reader, balanceOverrides: balanceOverrides}
+}
+
+func (r *overrideUTXOReader) GetBalance(addr string) (string, error) {
+	if balance, ok := r.balanceOverrides[addr]; ok {
+		return balance, nil
+	}
+	return r.UTXOReader.GetBalance(addr)
+}
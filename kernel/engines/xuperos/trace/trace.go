@@ -0,0 +1,94 @@
+// Package trace 提供交易模拟执行时的调用跟踪能力，记录每一层合约调用的
+// gas 消耗、读写集变化和内部调用关系，供 Chain.SimulateTx 返回给调用方调试使用。
+package trace
+
+// StorageDiff 描述一次存储读写的前后值
+type StorageDiff struct {
+	Key      []byte
+	OldValue []byte
+	NewValue []byte
+}
+
+// CallFrame 记录一次合约调用(包括合约间的内部调用)的完整轨迹
+type CallFrame struct {
+	Depth        int
+	ContractName string
+	MethodName   string
+	GasUsed      int64
+	Reads        []StorageDiff
+	Writes       []StorageDiff
+	Events       [][]byte
+	RevertReason string
+	Children     []*CallFrame
+}
+
+// Tracer 在模拟执行期间累积调用帧，NewFrame/EndFrame 成对调用以维护调用栈深度
+type Tracer struct {
+	root  []*CallFrame
+	stack []*CallFrame
+}
+
+// NewTracer 创建一个空的 Tracer
+func NewTracer() *Tracer {
+	return &Tracer{}
+}
+
+// NewFrame 为当前调用栈顶追加一个子调用帧并压栈，栈为空时作为根调用
+func (tr *Tracer) NewFrame(contractName, methodName string) *CallFrame {
+	frame := &CallFrame{
+		Depth:        len(tr.stack),
+		ContractName: contractName,
+		MethodName:   methodName,
+	}
+
+	if len(tr.stack) == 0 {
+		tr.root = append(tr.root, frame)
+	} else {
+		parent := tr.stack[len(tr.stack)-1]
+		parent.Children = append(parent.Children, frame)
+	}
+	tr.stack = append(tr.stack, frame)
+	return frame
+}
+
+// EndFrame 出栈，gasUsed/revertReason 补齐到本次调用帧
+func (tr *Tracer) EndFrame(gasUsed int64, revertReason string) {
+	if len(tr.stack) == 0 {
+		return
+	}
+	frame := tr.stack[len(tr.stack)-1]
+	frame.GasUsed = gasUsed
+	frame.RevertReason = revertReason
+	tr.stack = tr.stack[:len(tr.stack)-1]
+}
+
+// RecordStorageRead/RecordStorageWrite 记录当前栈顶调用帧的存储访问
+func (tr *Tracer) RecordStorageRead(key, value []byte) {
+	if len(tr.stack) == 0 {
+		return
+	}
+	frame := tr.stack[len(tr.stack)-1]
+	frame.Reads = append(frame.Reads, StorageDiff{Key: key, OldValue: value})
+}
+
+func (tr *Tracer) RecordStorageWrite(key, oldValue, newValue []byte) {
+	if len(tr.stack) == 0 {
+		return
+	}
+	frame := tr.stack[len(tr.stack)-1]
+	frame.Writes = append(frame.Writes, StorageDiff{Key: key, OldValue: oldValue, NewValue: newValue})
+}
+
+// RecordEvent 记录当前栈顶调用帧抛出的事件
+func (tr *Tracer) RecordEvent(event []byte) {
+	if len(tr.stack) == 0 {
+		return
+	}
+	frame := tr.stack[len(tr.stack)-1]
+	frame.Events = append(frame.Events, event)
+}
+
+// Frames 返回本次模拟执行所有根调用帧(树状结构)
+func (tr *Tracer) Frames() []*CallFrame {
+	return tr.root
+}
@@ -0,0 +1,32 @@
+package trace
+
+import "testing"
+
+func TestTracerNestedFrames(t *testing.T) {
+	tr := NewTracer()
+
+	tr.NewFrame("contractA", "methodA")
+	tr.RecordStorageWrite([]byte("k1"), []byte("old"), []byte("new"))
+	tr.NewFrame("contractB", "methodB")
+	tr.RecordEvent([]byte("event-from-b"))
+	tr.EndFrame(10, "")
+	tr.EndFrame(20, "")
+
+	frames := tr.Frames()
+	if len(frames) != 1 {
+		t.Fatalf("expected 1 root frame, got %d", len(frames))
+	}
+	root := frames[0]
+	if root.ContractName != "contractA" || root.GasUsed != 20 {
+		t.Fatalf("unexpected root frame: %+v", root)
+	}
+	if len(root.Writes) != 1 || string(root.Writes[0].NewValue) != "new" {
+		t.Fatalf("unexpected root writes: %+v", root.Writes)
+	}
+	if len(root.Children) != 1 || root.Children[0].ContractName != "contractB" {
+		t.Fatalf("unexpected children: %+v", root.Children)
+	}
+	if root.Children[0].GasUsed != 10 || len(root.Children[0].Events) != 1 {
+		t.Fatalf("unexpected child frame: %+v", root.Children[0])
+	}
+}